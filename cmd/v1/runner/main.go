@@ -1,5 +1,48 @@
 package main
 
-func main() {
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/conf"
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/runner"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/Shanghai-Lunara/publisher/pkg/utils/operators"
+	"github.com/nevercase/k8s-controller-custom-resource/pkg/signals"
+	"k8s.io/klog/v2"
+)
 
+// main runs a Runner with the Robot step, which was used for trying the Scheduler and the dashboard
+func main() {
+	var schedulerAddr = flag.String("schedulerAddr", "127.0.0.1:6969", "the address of the Scheduler")
+	var healthAddr = flag.String("healthAddr", ":6970", "the local address of the health and readiness probes")
+	var namespace = flag.String("namespace", "ns1", "the namespace of the Runner")
+	var groupName = flag.String("groupName", "update-data-robot", "the group of the Runner")
+	var name = flag.String("name", "robot", "the name of the Runner")
+	var drain = flag.Bool("drain", true, "finish the current step before exiting on SIGTERM")
+	var shutdownTimeoutInSec = flag.Int("shutdownTimeoutInSec", conf.DefaultShutdownTimeoutInSec, "the deadline of the graceful shutdown")
+	klog.InitFlags(nil)
+	flag.Parse()
+	stopCh := signals.SetupSignalHandler()
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	r := &runner.Runner{
+		Name:          *name,
+		Hostname:      hostname,
+		Namespace:     types.Namespace(*namespace),
+		GroupName:     types.GroupName(*groupName),
+		StepOperators: []interfaces.StepOperator{operators.NewRobot(types.StepPolicyAuto, 3000)},
+	}
+	c, err := runner.NewClient(*schedulerAddr, make(chan string, 1024), r)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	c.ServeHealth(*healthAddr)
+	if err = c.ShutdownOnSignal(stopCh, *drain, time.Second*time.Duration(*shutdownTimeoutInSec)); err != nil {
+		klog.Warningf("Runner shutting down err:%v", err)
+	}
 }
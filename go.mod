@@ -8,9 +8,16 @@ require (
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/gogo/protobuf v1.3.1
 	github.com/gorilla/websocket v1.4.2
+	github.com/jlaffaye/ftp v0.0.0-20200309171336-6841a2daa0d5
 	github.com/nevercase/k8s-controller-custom-resource v0.0.0-20201030040518-9e28262ecbf4
+	github.com/pkg/sftp v1.12.0
+	github.com/prometheus/client_golang v1.7.1
 	github.com/satori/go.uuid v1.2.0 // indirect
+	golang.org/x/crypto v0.0.0-20201016220609-9e8e0b390897
 	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776
+	k8s.io/api v0.17.3
+	k8s.io/apimachinery v0.17.3
+	k8s.io/client-go v0.17.3
 	k8s.io/klog v1.0.0
 	k8s.io/klog/v2 v2.4.0
 )
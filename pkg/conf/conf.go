@@ -2,6 +2,7 @@ package conf
 
 import (
 	"github.com/Shanghai-Lunara/publisher/pkg/dao"
+	"github.com/Shanghai-Lunara/publisher/pkg/notifier"
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"k8s.io/klog"
@@ -9,12 +10,20 @@ import (
 
 type PublisherService struct {
 	ListenPort int `json:"listenPort" yaml:"listenPort"`
+	// ShutdownTimeoutInSec was the deadline of the graceful shutdown, it would be DefaultShutdownTimeoutInSec if it was zero
+	ShutdownTimeoutInSec int `json:"shutdownTimeoutInSec" yaml:"shutdownTimeoutInSec"`
+	// TrustedProxies were the ips or cidrs of the reverse proxies, only their X-Forwarded-User and X-Forwarded-For
+	// headers would be trusted by the audits
+	TrustedProxies []string `json:"trustedProxies" yaml:"trustedProxies"`
 }
 
+const DefaultShutdownTimeoutInSec = 30
+
 type Config struct {
 	PublisherService PublisherService    `yaml:"PublisherService,flow"`
 	Mysql            dao.MysqlPoolConfig `yaml:"Mysql,flow"`
 	Projects         []Project           `yaml:"Projects"`
+	Notifications    notifier.Config     `yaml:"Notifications"`
 }
 
 type Project struct {
@@ -12,3 +12,18 @@ CREATE TABLE records (
     stepType TINYINT(1) DEFAULT 0 COMMENT '步骤类型',
     createdTM INT(11) NOT NULL
 );
+
+CREATE TABLE audits (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    PRIMARY KEY(id),
+    actor VARCHAR(128) NOT NULL DEFAULT '' COMMENT '操作的dashboard用户',
+    sourceIp VARCHAR(64) NOT NULL DEFAULT '' COMMENT '操作来源ip',
+    serviceApi VARCHAR(64) NOT NULL DEFAULT '' COMMENT '操作的ServiceAPI',
+    namespace VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'namespace项目命名空间',
+    groupName VARCHAR(128) NOT NULL DEFAULT '' COMMENT '项目分支渠道名称',
+    runnerName VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'runner名称',
+    stepName VARCHAR(128) NOT NULL DEFAULT '' COMMENT '步骤名称',
+    changes TEXT NOT NULL COMMENT '修改前后的差异json',
+    createdTM INT(11) NOT NULL DEFAULT 0,
+    KEY idx_namespace_group (namespace, groupName)
+);
@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// The audits table was created by the pkg/dababase/database.sql
+
+// InsertAudit writes the Audit into the Master, and the Changes would be stored as JSON.
+func (d *Dao) InsertAudit(a *types.Audit) (err error) {
+	changes, err := json.Marshal(a.Changes)
+	if err != nil {
+		klog.V(2).Info(err)
+		return err
+	}
+	_, err = d.Mysql.Master().Exec("INSERT INTO audits (`actor`,`sourceIp`,`serviceApi`,`namespace`,`groupName`,`runnerName`,`stepName`,`changes`,`createdTM`) values (?,?,?,?,?,?,?,?,?)",
+		a.Actor,
+		a.SourceIP,
+		a.ServiceAPI,
+		a.Namespace,
+		a.GroupName,
+		a.RunnerName,
+		a.StepName,
+		changes,
+		a.CreatedTM)
+	if err != nil {
+		klog.V(2).Info(err)
+		return err
+	}
+	return nil
+}
+
+// ListAudits returns the Audits which were matched by the request in the descending order of id,
+// and the total number of the matched Audits.
+func (d *Dao) ListAudits(req *types.ListAuditsRequest) (res []types.Audit, num int32, err error) {
+	where := "WHERE `namespace` = ? AND `groupName` = ?"
+	args := []interface{}{req.Namespace, req.GroupName}
+	if req.RunnerName != "" {
+		where += " AND `runnerName` = ?"
+		args = append(args, req.RunnerName)
+	}
+	if req.Actor != "" {
+		where += " AND `actor` = ?"
+		args = append(args, req.Actor)
+	}
+	db := d.Mysql.Master()
+	if err = db.QueryRow("SELECT count(*) FROM audits "+where, args...).Scan(&num); err != nil {
+		klog.V(2).Info(err)
+		return nil, 0, err
+	}
+	var rows *sql.Rows
+	rows, err = db.Query("SELECT `id`,`actor`,`sourceIp`,`serviceApi`,`namespace`,`groupName`,`runnerName`,`stepName`,`changes`,`createdTM` FROM audits "+where+" ORDER BY id DESC LIMIT ?, ?",
+		append(args, req.Page, req.Length)...)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+	res = make([]types.Audit, 0)
+	for rows.Next() {
+		a := types.Audit{}
+		var changes []byte
+		if err = rows.Scan(&a.Id, &a.Actor, &a.SourceIP, &a.ServiceAPI, &a.Namespace, &a.GroupName, &a.RunnerName, &a.StepName, &changes, &a.CreatedTM); err != nil {
+			klog.V(2).Info(err)
+			return nil, 0, err
+		}
+		if len(changes) > 0 {
+			if err = json.Unmarshal(changes, &a.Changes); err != nil {
+				klog.V(2).Info(err)
+				return nil, 0, err
+			}
+		}
+		res = append(res, a)
+	}
+	if err = rows.Err(); err != nil {
+		klog.V(2).Info(err)
+		return nil, 0, err
+	}
+	return res, num, nil
+}
@@ -1,6 +1,7 @@
 package dao
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
@@ -72,3 +73,8 @@ func (mp *MysqlPool) Master() *sql.DB {
 func (mp *MysqlPool) Slave() *sql.DB {
 	return mp.slave
 }
+
+// Ping verifies the connection of the Master is still alive
+func (mp *MysqlPool) Ping(ctx context.Context) error {
+	return mp.master.PingContext(ctx)
+}
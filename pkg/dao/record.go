@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ErrRecordWasNotExisted = "error: the record id:%d was not existed"
+
+	// SucceededVersionsScanLimit was the number of the latest version records which would be scanned for the succeeded
+	// ones, because the phase was stored in the stepInfo and the older releases were unlikely to be rolled back to
+	SucceededVersionsScanLimit = 500
+)
+
+const recordColumns = "`id`,`namespace`,`groupName`,`runnerName`,`stepInfo`,`stepType`,`createdTM`"
+
+// GetRecord returns the record by the id
+func (d *Dao) GetRecord(id int32) (*types.Record, error) {
+	r := &types.Record{}
+	err := d.Mysql.Master().QueryRow("SELECT "+recordColumns+" FROM records WHERE `id` = ?", id).
+		Scan(&r.Id, &r.Namespace, &r.GroupName, &r.RunnerName, &r.StepInfo, &r.StepType, &r.CreatedTM)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf(ErrRecordWasNotExisted, id)
+		}
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListSucceededVersions returns the version records whose Steps had been succeeded in the descending order of id,
+// and the total number of them. The phase was stored in the stepInfo, so the records were filtered after decoding,
+// and only the latest SucceededVersionsScanLimit version records were scanned.
+func (d *Dao) ListSucceededVersions(req *types.ListRecordsRequest) (res []types.Record, num int32, err error) {
+	where := "WHERE `namespace` = ? AND `groupName` = ? AND `stepType` = ?"
+	args := []interface{}{req.Namespace, req.GroupName, types.RecordVersion}
+	if req.RunnerName != "" {
+		where += " AND `runnerName` = ?"
+		args = append(args, req.RunnerName)
+	}
+	rows, err := d.Mysql.Master().Query("SELECT "+recordColumns+" FROM records "+where+" ORDER BY id DESC LIMIT ?", append(args, SucceededVersionsScanLimit)...)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+	res = make([]types.Record, 0)
+	for rows.Next() {
+		r := types.Record{}
+		if err = rows.Scan(&r.Id, &r.Namespace, &r.GroupName, &r.RunnerName, &r.StepInfo, &r.StepType, &r.CreatedTM); err != nil {
+			klog.V(2).Info(err)
+			return nil, 0, err
+		}
+		step := &types.Step{}
+		if err = step.Unmarshal(r.StepInfo); err != nil {
+			klog.V(2).Info(err)
+			continue
+		}
+		if step.Phase != types.StepSucceeded {
+			continue
+		}
+		if num >= req.Page && int32(len(res)) < req.Length {
+			res = append(res, r)
+		}
+		num++
+	}
+	if err = rows.Err(); err != nil {
+		klog.V(2).Info(err)
+		return nil, 0, err
+	}
+	return res, num, nil
+}
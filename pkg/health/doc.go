@@ -0,0 +1,3 @@
+// Package health contains the http handlers of the liveness and readiness probes which were
+// exposed by both the Scheduler and the Runner.
+package health
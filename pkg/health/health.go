@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+
+	// DefaultCheckTimeout was the max duration of all the checks in one probe
+	DefaultCheckTimeout = time.Second * 3
+)
+
+// Check was a named dependency check, it returns nil if the dependency was ready.
+type Check struct {
+	Name string
+	Func func(ctx context.Context) error
+}
+
+// CheckStatus was the result of a Check
+type CheckStatus struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Status was the JSON body which would be written by the probe handlers
+type Status struct {
+	Status string            `json:"status"`
+	Checks []CheckStatus     `json:"checks,omitempty"`
+	Info   map[string]string `json:"info,omitempty"`
+}
+
+// LivenessHandler reports that the process was alive, and it never checks any dependency.
+func LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		write(w, &Status{Status: StatusOK})
+	}
+}
+
+// ReadinessHandler runs all the checks in order, and it responds with http.StatusServiceUnavailable
+// when any of them failed. The info func was optional and it would be called for extra details.
+func ReadinessHandler(checks []Check, info func() map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), DefaultCheckTimeout)
+		defer cancel()
+		s := Run(ctx, checks)
+		if info != nil {
+			s.Info = info()
+		}
+		write(w, s)
+	}
+}
+
+// Run executes the checks and collects their results
+func Run(ctx context.Context, checks []Check) *Status {
+	s := &Status{
+		Status: StatusOK,
+		Checks: make([]CheckStatus, 0, len(checks)),
+	}
+	for _, v := range checks {
+		cs := CheckStatus{
+			Name:   v.Name,
+			Status: StatusOK,
+		}
+		if err := v.Func(ctx); err != nil {
+			klog.V(2).Infof("health check:%s err:%v", v.Name, err)
+			cs.Status = StatusFailed
+			cs.Message = err.Error()
+			s.Status = StatusFailed
+		}
+		s.Checks = append(s.Checks, cs)
+	}
+	return s
+}
+
+func write(w http.ResponseWriter, s *Status) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		klog.V(2).Info(err)
+	}
+}
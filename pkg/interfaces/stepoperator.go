@@ -8,3 +8,9 @@ type StepOperator interface {
 	Prepare()
 	Run(output chan<- string) (res []string, err error)
 }
+
+// Canceler was implemented by the StepOperators whose running Step could be cancelled,
+// the cancelled Run should return as soon as possible with an error
+type Canceler interface {
+	Cancel()
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+)
+
+// The payload formats of the chat incoming webhooks
+const (
+	ChatFormatSlack    = "slack"
+	ChatFormatDingTalk = "dingtalk"
+	ChatFormatWeCom    = "wecom"
+	ChatFormatFeishu   = "feishu"
+
+	ErrUnknownChatFormat = "error: notifier:%s chat format:%s was unknown"
+)
+
+// chat posts the rendered Template as a text message to the incoming webhook of a chat group
+type chat struct {
+	name     string
+	url      string
+	format   string
+	headers  map[string]string
+	template *template.Template
+	client   *http.Client
+}
+
+func NewChat(c NotifierConfig) (Notifier, error) {
+	switch c.Format {
+	case ChatFormatSlack, ChatFormatDingTalk, ChatFormatWeCom, ChatFormatFeishu:
+	default:
+		return nil, fmt.Errorf(ErrUnknownChatFormat, c.Name, c.Format)
+	}
+	text := c.Template
+	if text == "" {
+		text = DefaultTextTemplate
+	}
+	t, err := parseTemplate(c.Name, text)
+	if err != nil {
+		return nil, err
+	}
+	return &chat{
+		name:     c.Name,
+		url:      c.URL,
+		format:   c.Format,
+		headers:  c.Headers,
+		template: t,
+		client:   &http.Client{},
+	}, nil
+}
+
+func (c *chat) Name() string {
+	return c.name
+}
+
+func (c *chat) Notify(ctx context.Context, e *Event) error {
+	text, err := render(c.template, e)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(chatPayload(c.format, text))
+	if err != nil {
+		return err
+	}
+	return post(ctx, c.client, c.name, http.MethodPost, c.url, c.headers, body)
+}
+
+func chatPayload(format, text string) interface{} {
+	switch format {
+	case ChatFormatDingTalk, ChatFormatWeCom:
+		return map[string]interface{}{
+			"msgtype": "text",
+			"text": map[string]string{
+				"content": text,
+			},
+		}
+	case ChatFormatFeishu:
+		return map[string]interface{}{
+			"msg_type": "text",
+			"content": map[string]string{
+				"text": text,
+			},
+		}
+	default:
+		return map[string]string{
+			"text": text,
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package notifier
+
+const (
+	TypeWebhook = "webhook"
+	TypeSmtp    = "smtp"
+	TypeChat    = "chat"
+)
+
+// Config was the configuration of all the notifiers and the routing rules
+type Config struct {
+	Notifiers []NotifierConfig `json:"notifiers" yaml:"notifiers"`
+	Routes    []Route          `json:"routes" yaml:"routes"`
+}
+
+type NotifierConfig struct {
+	// Name was the unique name which would be referenced by the Routes
+	Name string `json:"name" yaml:"name"`
+	// Type was one of the TypeWebhook, TypeSmtp and TypeChat
+	Type string `json:"type" yaml:"type"`
+	// Template was the text/template of the message, it would be rendered with an Event.
+	// For the TypeWebhook it was the JSON body, and for the others it was the plain text.
+	Template     string `json:"template" yaml:"template"`
+	TimeoutInSec int    `json:"timeoutInSec" yaml:"timeoutInSec"`
+
+	// URL was the address of the TypeWebhook and TypeChat
+	URL     string            `json:"url" yaml:"url"`
+	Method  string            `json:"method" yaml:"method"`
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	// Format was the payload format of the TypeChat, such as ChatFormatSlack, ChatFormatDingTalk
+	Format string `json:"format" yaml:"format"`
+
+	// the configuration of the TypeSmtp
+	Host     string   `json:"host" yaml:"host"`
+	Port     int      `json:"port" yaml:"port"`
+	Username string   `json:"username" yaml:"username"`
+	Password string   `json:"password" yaml:"password"`
+	From     string   `json:"from" yaml:"from"`
+	To       []string `json:"to" yaml:"to"`
+	// Subject was the text/template of the mail subject
+	Subject string `json:"subject" yaml:"subject"`
+}
+
+// Route determines which notifiers would be fired by an Event
+type Route struct {
+	// Namespace matches all the namespaces if it was empty or `*`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Groups matches all the groups in the Namespace if it was empty
+	Groups []string `json:"groups" yaml:"groups"`
+	// Events matches all the EventType if it was empty
+	Events    []EventType `json:"events" yaml:"events"`
+	Notifiers []string    `json:"notifiers" yaml:"notifiers"`
+}
+
+func (r *Route) match(e *Event) bool {
+	if r.Namespace != "" && r.Namespace != "*" && r.Namespace != e.Namespace {
+		return false
+	}
+	if len(r.Groups) > 0 && !contains(r.Groups, e.GroupName) {
+		return false
+	}
+	if len(r.Events) > 0 {
+		matched := false
+		for _, v := range r.Events {
+			if v == e.Type {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(items []string, s string) bool {
+	for _, v := range items {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,3 @@
+// Package notifier sends the notifications of the step outcomes and the Runner disconnections
+// to the configured webhooks, mailboxes and chat groups.
+package notifier
@@ -0,0 +1,120 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+type EventType string
+
+const (
+	EventStepSucceeded      EventType = "StepSucceeded"
+	EventStepFailed         EventType = "StepFailed"
+	EventRunnerDisconnected EventType = "RunnerDisconnected"
+)
+
+// Event was the context of a notification, and it would be rendered by the templates
+type Event struct {
+	Type         EventType `json:"type"`
+	Namespace    string    `json:"namespace"`
+	GroupName    string    `json:"groupName"`
+	RunnerName   string    `json:"runnerName"`
+	StepName     string    `json:"stepName,omitempty"`
+	Phase        string    `json:"phase,omitempty"`
+	DurationInMS int32     `json:"durationInMs,omitempty"`
+	Messages     []string  `json:"messages,omitempty"`
+	Remarks      []string  `json:"remarks,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+// NewStepEvent creates the Event of a finished Step, and it returns nil if the Step was not finished
+func NewStepEvent(namespace types.Namespace, groupName types.GroupName, runnerName string, step *types.Step) *Event {
+	e := &Event{
+		Namespace:    string(namespace),
+		GroupName:    string(groupName),
+		RunnerName:   runnerName,
+		StepName:     step.Name,
+		Phase:        string(step.Phase),
+		DurationInMS: step.DurationInMS,
+		Messages:     step.Messages,
+		Remarks:      step.Remarks,
+		Time:         time.Now(),
+	}
+	switch step.Phase {
+	case types.StepSucceeded:
+		e.Type = EventStepSucceeded
+	case types.StepFailed:
+		e.Type = EventStepFailed
+	default:
+		return nil
+	}
+	return e
+}
+
+// NewRunnerDisconnectedEvent creates the Event of a disconnected Runner
+func NewRunnerDisconnectedEvent(namespace types.Namespace, groupName types.GroupName, runnerName string) *Event {
+	return &Event{
+		Type:       EventRunnerDisconnected,
+		Namespace:  string(namespace),
+		GroupName:  string(groupName),
+		RunnerName: runnerName,
+		Time:       time.Now(),
+	}
+}
+
+const (
+	// DefaultTextTemplate was used by the TypeSmtp and TypeChat if the Template was empty
+	DefaultTextTemplate = `[{{.Type}}] {{.Namespace}}/{{.GroupName}}/{{.RunnerName}}{{if .StepName}} step:{{.StepName}} phase:{{.Phase}} duration:{{duration .DurationInMS}}{{end}}
+{{- with svnLog .Remarks}}
+{{.}}{{end}}
+{{- range .Messages}}
+{{.}}{{end}}`
+	// DefaultSubjectTemplate was used by the TypeSmtp if the Subject was empty
+	DefaultSubjectTemplate = `[publisher] {{.Type}} {{.Namespace}}/{{.GroupName}}/{{.RunnerName}} {{.StepName}}`
+)
+
+var templateFuncs = template.FuncMap{
+	// json encodes the value, it was useful for the webhook JSON body
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join": strings.Join,
+	// duration formats the DurationInMS
+	"duration": func(ms int32) string {
+		return (time.Duration(ms) * time.Millisecond).String()
+	},
+	"svnLog": svnLog,
+}
+
+// svnLog returns the `svn log` remark which was appended by the svn operator in the committing mode,
+// or the resolved revision remark of the pulling mode
+func svnLog(remarks []string) string {
+	resolved := ""
+	for _, v := range remarks {
+		if strings.Contains(v, "Revision:") {
+			return strings.TrimSpace(v)
+		}
+		if resolved == "" && strings.HasPrefix(v, types.SvnRevisionRemarkPrefix) {
+			resolved = strings.TrimSpace(v)
+		}
+	}
+	return resolved
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Parse(text)
+}
+
+func render(t *template.Template, e *Event) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, e); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
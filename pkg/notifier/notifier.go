@@ -0,0 +1,118 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	DefaultTimeoutInSec = 10
+
+	ErrUnknownNotifierType = "error: notifier:%s type:%s was unknown"
+	ErrDuplicatedNotifier  = "error: notifier:%s was duplicated"
+	ErrNotifierNotExisted  = "error: notifier:%s which was referenced by the route was not existed"
+)
+
+// Notifier sends an Event to the remote receivers
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, e *Event) error
+}
+
+// Dispatcher routes the Events to the Notifiers in the background
+type Dispatcher struct {
+	notifiers map[string]Notifier
+	timeouts  map[string]time.Duration
+	routes    []Route
+	wg        sync.WaitGroup
+}
+
+func New(c *Config) (*Dispatcher, error) {
+	d := &Dispatcher{
+		notifiers: make(map[string]Notifier, 0),
+		timeouts:  make(map[string]time.Duration, 0),
+		routes:    c.Routes,
+	}
+	for _, v := range c.Notifiers {
+		if _, ok := d.notifiers[v.Name]; ok {
+			return nil, fmt.Errorf(ErrDuplicatedNotifier, v.Name)
+		}
+		var n Notifier
+		var err error
+		switch v.Type {
+		case TypeWebhook:
+			n, err = NewWebhook(v)
+		case TypeChat:
+			n, err = NewChat(v)
+		case TypeSmtp:
+			n, err = NewSmtp(v)
+		default:
+			err = fmt.Errorf(ErrUnknownNotifierType, v.Name, v.Type)
+		}
+		if err != nil {
+			return nil, err
+		}
+		d.notifiers[v.Name] = n
+		timeout := v.TimeoutInSec
+		if timeout <= 0 {
+			timeout = DefaultTimeoutInSec
+		}
+		d.timeouts[v.Name] = time.Second * time.Duration(timeout)
+	}
+	for _, v := range c.Routes {
+		for _, name := range v.Notifiers {
+			if _, ok := d.notifiers[name]; !ok {
+				return nil, fmt.Errorf(ErrNotifierNotExisted, name)
+			}
+		}
+	}
+	return d, nil
+}
+
+// Dispatch sends the Event to all the Notifiers of the matched Routes without blocking,
+// and each Notifier would be fired only once for an Event.
+func (d *Dispatcher) Dispatch(e *Event) {
+	if e == nil {
+		return
+	}
+	fired := make(map[string]bool, 0)
+	for _, r := range d.routes {
+		if !r.match(e) {
+			continue
+		}
+		for _, name := range r.Notifiers {
+			if fired[name] {
+				continue
+			}
+			fired[name] = true
+			d.wg.Add(1)
+			go func(n Notifier, timeout time.Duration) {
+				defer d.wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+				if err := n.Notify(ctx, e); err != nil {
+					klog.Warningf("notifier:%s event:%s err:%v", n.Name(), e.Type, err)
+				}
+			}(d.notifiers[name], d.timeouts[name])
+		}
+	}
+}
+
+// Wait blocks until all the pending notifications have been sent or the ctx was done
+func (d *Dispatcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
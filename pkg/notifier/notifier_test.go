@@ -0,0 +1,297 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+var fakeStep = &types.Step{
+	Name:         "SVN-Operator",
+	Phase:        types.StepSucceeded,
+	DurationInMS: 1500,
+	Remarks:      []string{fmt.Sprintf(types.SvnRevisionRemarkFormat, "42", "svn://svn.example.com/game/trunk")},
+}
+
+// fakeSmtpServer was a minimal SMTP stand-in which accepts one mail for each connection
+type fakeSmtpServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	mails    []string
+}
+
+func newFakeSmtpServer(t *testing.T) *fakeSmtpServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSmtpServer{listener: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSmtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	write := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+	write("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			write("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+			write("250 OK")
+		case cmd == "DATA":
+			write("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.mu.Lock()
+			s.mails = append(s.mails, data.String())
+			s.mu.Unlock()
+			write("250 OK")
+		case cmd == "QUIT":
+			write("221 bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Config
+		wantErr bool
+	}{
+		{
+			name: "TestNew_1",
+			c: &Config{
+				Notifiers: []NotifierConfig{{Name: "a", Type: TypeWebhook, URL: "http://127.0.0.1"}},
+				Routes:    []Route{{Notifiers: []string{"a"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "TestNew_2",
+			c: &Config{
+				Notifiers: []NotifierConfig{{Name: "a", Type: "unknown"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TestNew_3",
+			c: &Config{
+				Notifiers: []NotifierConfig{{Name: "a", Type: TypeWebhook}},
+				Routes:    []Route{{Notifiers: []string{"b"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TestNew_4",
+			c: &Config{
+				Notifiers: []NotifierConfig{{Name: "a", Type: TypeChat, Format: "unknown"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.c); (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string][]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received[r.URL.Path] = append(received[r.URL.Path], string(body))
+		mu.Unlock()
+	}))
+	defer server.Close()
+	smtpServer := newFakeSmtpServer(t)
+	defer smtpServer.listener.Close()
+	host, port, _ := net.SplitHostPort(smtpServer.listener.Addr().String())
+	var p int
+	fmt.Sscanf(port, "%d", &p)
+
+	d, err := New(&Config{
+		Notifiers: []NotifierConfig{
+			{
+				Name:     "webhook",
+				Type:     TypeWebhook,
+				URL:      server.URL + "/webhook",
+				Template: `{"step":{{json .StepName}},"duration":{{.DurationInMS}},"svn":{{json (svnLog .Remarks)}}}`,
+			},
+			{
+				Name:   "dingtalk",
+				Type:   TypeChat,
+				Format: ChatFormatDingTalk,
+				URL:    server.URL + "/dingtalk",
+			},
+			{
+				Name: "mail",
+				Type: TypeSmtp,
+				Host: host,
+				Port: p,
+				From: "publisher@example.com",
+				To:   []string{"ops@example.com"},
+			},
+		},
+		Routes: []Route{
+			{Namespace: "ns1", Events: []EventType{EventStepSucceeded}, Notifiers: []string{"webhook", "mail"}},
+			{Namespace: "*", Groups: []string{"g1"}, Notifiers: []string{"webhook", "dingtalk"}},
+			{Namespace: "ns2", Notifiers: []string{"mail"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Dispatch(NewStepEvent("ns1", "g1", "runner-1", fakeStep))
+	d.Dispatch(NewRunnerDisconnectedEvent("ns3", "g2", "runner-2"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := d.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(received["/webhook"]); got != 1 {
+		t.Fatalf("webhook received %d requests, want 1", got)
+	}
+	webhook := make(map[string]interface{}, 0)
+	if err := json.Unmarshal([]byte(received["/webhook"][0]), &webhook); err != nil {
+		t.Fatalf("webhook body:%s err:%v", received["/webhook"][0], err)
+	}
+	if webhook["step"] != "SVN-Operator" || webhook["duration"] != float64(1500) || !strings.Contains(webhook["svn"].(string), "svn revision: 42 url: svn://svn.example.com/game/trunk") {
+		t.Errorf("webhook body = %v", webhook)
+	}
+
+	if got := len(received["/dingtalk"]); got != 1 {
+		t.Fatalf("dingtalk received %d requests, want 1", got)
+	}
+	chat := struct {
+		MsgType string `json:"msgtype"`
+		Text    struct {
+			Content string `json:"content"`
+		} `json:"text"`
+	}{}
+	if err := json.Unmarshal([]byte(received["/dingtalk"][0]), &chat); err != nil {
+		t.Fatal(err)
+	}
+	if chat.MsgType != "text" || !strings.Contains(chat.Text.Content, "[StepSucceeded] ns1/g1/runner-1 step:SVN-Operator phase:Succeeded duration:1.5s") {
+		t.Errorf("dingtalk body = %v", received["/dingtalk"][0])
+	}
+
+	smtpServer.mu.Lock()
+	defer smtpServer.mu.Unlock()
+	if len(smtpServer.mails) != 1 {
+		t.Fatalf("smtp received %d mails, want 1", len(smtpServer.mails))
+	}
+	if !strings.Contains(smtpServer.mails[0], "Subject: [publisher] StepSucceeded ns1/g1/runner-1 SVN-Operator") ||
+		!strings.Contains(smtpServer.mails[0], "svn revision: 42 url: svn://svn.example.com/game/trunk") {
+		t.Errorf("smtp mail = %s", smtpServer.mails[0])
+	}
+}
+
+func TestRoute_match(t *testing.T) {
+	e := NewStepEvent("ns1", "g1", "runner-1", &types.Step{Name: "a", Phase: types.StepFailed})
+	tests := []struct {
+		name  string
+		route Route
+		want  bool
+	}{
+		{name: "TestRoute_match_1", route: Route{}, want: true},
+		{name: "TestRoute_match_2", route: Route{Namespace: "ns2"}, want: false},
+		{name: "TestRoute_match_3", route: Route{Namespace: "ns1", Groups: []string{"g2"}}, want: false},
+		{name: "TestRoute_match_4", route: Route{Namespace: "ns1", Events: []EventType{EventStepSucceeded}}, want: false},
+		{name: "TestRoute_match_5", route: Route{Namespace: "ns1", Groups: []string{"g1"}, Events: []EventType{EventStepFailed}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.route.match(e); got != tt.want {
+				t.Errorf("Route.match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_smtpSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		want    string
+	}{
+		{name: "Test_smtpSubject_1", subject: "[publisher] StepFailed ns1/g1/runner-1 a\n", want: "[publisher] StepFailed ns1/g1/runner-1 a"},
+		{name: "Test_smtpSubject_2", subject: "a\r\nBcc: mallory@example.com", want: "a Bcc: mallory@example.com"},
+		{name: "Test_smtpSubject_3", subject: "发布 a", want: "=?utf-8?q?=E5=8F=91=E5=B8=83_a?="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := smtpSubject(tt.subject); got != tt.want {
+				t.Errorf("smtpSubject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_svnLog(t *testing.T) {
+	resolved := fmt.Sprintf(types.SvnRevisionRemarkFormat, "42", "svn://svn.example.com/game/trunk")
+	log := "\nRevision:   43\nAuthor:     robot\nDateTime:   2020-10-30 10:00:00 +0000 UTC\nMsg:        publish\n"
+	tests := []struct {
+		name    string
+		remarks []string
+		want    string
+	}{
+		{name: "Test_svnLog_1", remarks: []string{resolved}, want: resolved},
+		{name: "Test_svnLog_2", remarks: []string{resolved, log}, want: strings.TrimSpace(log)},
+		{name: "Test_svnLog_3", remarks: []string{"nothing to commit"}, want: ""},
+		{name: "Test_svnLog_4", remarks: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := svnLog(tt.remarks); got != tt.want {
+				t.Errorf("svnLog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
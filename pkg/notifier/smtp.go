@@ -0,0 +1,161 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	ErrSmtpNoRecipients = "error: notifier:%s there were no recipients"
+)
+
+// smtpNotifier sends the rendered Template as a plain text mail
+type smtpNotifier struct {
+	name     string
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+	subject  *template.Template
+	template *template.Template
+}
+
+func NewSmtp(c NotifierConfig) (Notifier, error) {
+	if len(c.To) == 0 {
+		return nil, fmt.Errorf(ErrSmtpNoRecipients, c.Name)
+	}
+	text := c.Template
+	if text == "" {
+		text = DefaultTextTemplate
+	}
+	t, err := parseTemplate(c.Name, text)
+	if err != nil {
+		return nil, err
+	}
+	subject := c.Subject
+	if subject == "" {
+		subject = DefaultSubjectTemplate
+	}
+	st, err := parseTemplate(c.Name+"-subject", subject)
+	if err != nil {
+		return nil, err
+	}
+	return &smtpNotifier{
+		name:     c.Name,
+		addr:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		host:     c.Host,
+		username: c.Username,
+		password: c.Password,
+		from:     c.From,
+		to:       c.To,
+		subject:  st,
+		template: t,
+	}, nil
+}
+
+func (s *smtpNotifier) Name() string {
+	return s.name
+}
+
+func (s *smtpNotifier) Notify(ctx context.Context, e *Event) error {
+	subject, err := render(s.subject, e)
+	if err != nil {
+		return err
+	}
+	body, err := render(s.template, e)
+	if err != nil {
+		return err
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", smtpSubject(subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.Replace(body, "\n", "\r\n", -1))
+	msg.WriteString("\r\n")
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.send(ctx, auth, msg.Bytes())
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// smtpSubject folds the CR and LF of the rendered subject, so that the template values could not inject headers,
+// and the non-ASCII subject would be encoded as the RFC 2047
+func smtpSubject(subject string) string {
+	subject = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(subject)
+	return mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject))
+}
+
+// send was the same as smtp.SendMail, but the dialing and the whole session were bounded by the ctx
+func (s *smtpNotifier) send(ctx context.Context, auth smtp.Auth, msg []byte) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err = c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(s.from); err != nil {
+		return err
+	}
+	for _, v := range s.to {
+		if err = c.Rcpt(v); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"text/template"
+)
+
+const (
+	// DefaultWebhookTemplate posts the whole Event as the JSON body
+	DefaultWebhookTemplate = `{{json .}}`
+
+	ErrUnexpectedStatusCode = "error: notifier:%s unexpected status code:%d body:%s"
+)
+
+// webhook posts the rendered Template to the URL
+type webhook struct {
+	name     string
+	url      string
+	method   string
+	headers  map[string]string
+	template *template.Template
+	client   *http.Client
+}
+
+func NewWebhook(c NotifierConfig) (Notifier, error) {
+	text := c.Template
+	if text == "" {
+		text = DefaultWebhookTemplate
+	}
+	t, err := parseTemplate(c.Name, text)
+	if err != nil {
+		return nil, err
+	}
+	method := c.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	return &webhook{
+		name:     c.Name,
+		url:      c.URL,
+		method:   method,
+		headers:  c.Headers,
+		template: t,
+		client:   &http.Client{},
+	}, nil
+}
+
+func (w *webhook) Name() string {
+	return w.name
+}
+
+func (w *webhook) Notify(ctx context.Context, e *Event) error {
+	body, err := render(w.template, e)
+	if err != nil {
+		return err
+	}
+	return post(ctx, w.client, w.name, w.method, w.url, w.headers, []byte(body))
+}
+
+func post(ctx context.Context, client *http.Client, name, method, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		t, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf(ErrUnexpectedStatusCode, name, res.StatusCode, string(t))
+	}
+	return nil
+}
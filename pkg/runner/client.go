@@ -7,16 +7,31 @@ import (
 	"github.com/gorilla/websocket"
 	"k8s.io/klog/v2"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Client struct {
-	conn         *websocket.Conn
-	writeChan    chan []byte
+	// connected would be 1 while the websocket connection to the Scheduler was alive
+	connected int32
+	// draining would be 1 after the Client started shutting down, and the new steps would be refused
+	draining int32
+	// closed would be 1 after the websocket connection was closed by the Client or the Scheduler on purpose
+	closed    int32
+	conn      *websocket.Conn
+	writeChan chan []byte
+	// flushed would be closed by the writePump after the messages before the nil in the writeChan were sent
+	flushed      chan struct{}
 	runner       *Runner
 	streamOutput chan string
 	pingTimer    bool
+	// mu protects the currentStep and the currentPhase, which were also read by the health probes
+	mu           sync.Mutex
 	currentStep  *types.Step
+	currentPhase types.StepPhase
+	running      sync.WaitGroup
+	closeOnce    sync.Once
 	ctx          context.Context
 	cancel       context.CancelFunc
 }
@@ -33,12 +48,14 @@ func NewClient(addr string, streamOutput chan string, r *Runner) (*Client, error
 	c := &Client{
 		conn:         a,
 		writeChan:    make(chan []byte, 1024),
+		flushed:      make(chan struct{}),
 		runner:       r,
 		streamOutput: streamOutput,
 		pingTimer:    false,
 		ctx:          ctx,
 		cancel:       cancel,
 	}
+	atomic.StoreInt32(&c.connected, 1)
 	c.runner.StreamOutput = c.streamOutput
 	go c.readPump()
 	go c.writePump()
@@ -104,6 +121,12 @@ func (c *Client) readPump() {
 		messageType, message, err := c.conn.ReadMessage()
 		klog.V(5).Infof("messageType: %d message: %s err:%v\n", messageType, string(message), err)
 		if err != nil {
+			atomic.StoreInt32(&c.connected, 0)
+			if atomic.LoadInt32(&c.closed) == 1 {
+				klog.Info("Client websocket connection was closed")
+				c.close()
+				return
+			}
 			klog.Fatal(err)
 			return
 		}
@@ -119,17 +142,30 @@ func (c *Client) readPump() {
 				go c.ping()
 			}
 		case types.Ping:
+		case types.Shutdown:
+			// the Scheduler was shutting down, so the following disconnection was expected
+			klog.Info("Scheduler was shutting down")
+			atomic.StoreInt32(&c.closed, 1)
 		case types.RunStep:
 			data := &types.RunStepRequest{}
 			if err = data.Unmarshal(req.Data); err != nil {
 				klog.Fatal(err)
 			}
+			if atomic.LoadInt32(&c.draining) == 1 {
+				c.refuseStep(&data.Step)
+				continue
+			}
+			c.running.Add(1)
 			go func() {
-				c.currentStep = &data.Step
+				defer c.running.Done()
+				c.setCurrentStep(&data.Step, types.StepRunning)
 				if err = c.runner.Run(&data.Step); err != nil {
 					klog.V(2).Info(err)
 					// todo catching error, update Step's Messages, and report to Scheduler
 				}
+				if t, err := c.runner.Step(&data.Step); err == nil {
+					c.setCurrentStep(&data.Step, t.Phase)
+				}
 				if err = c.updateStepInformationToScheduler(&data.Step); err != nil {
 					klog.Fatal(err)
 				}
@@ -155,7 +191,16 @@ func (c *Client) writePump() {
 			if !isClose {
 				return
 			}
+			if msg == nil {
+				close(c.flushed)
+				return
+			}
 			if err := c.conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+				atomic.StoreInt32(&c.connected, 0)
+				if atomic.LoadInt32(&c.closed) == 1 {
+					klog.V(2).Info(err)
+					return
+				}
 				klog.Fatal(err)
 				return
 			}
@@ -172,7 +217,8 @@ func (c *Client) logStream() {
 			if !isClose {
 				return
 			}
-			if c.currentStep == nil {
+			s, _ := c.getCurrentStep()
+			if s == nil {
 				klog.V(2).Info("Client currentStep was nil")
 				continue
 			}
@@ -180,8 +226,9 @@ func (c *Client) logStream() {
 				Namespace:  c.runner.Namespace,
 				GroupName:  c.runner.GroupName,
 				RunnerName: c.runner.Name,
-				StepName:   c.currentStep.Name,
+				StepName:   s.Name,
 				Output:     log,
+				Timestamp:  time.Now().UnixNano() / int64(time.Millisecond),
 			}
 			data, err := req1.Marshal()
 			if err != nil {
@@ -203,6 +250,20 @@ func (c *Client) logStream() {
 	}
 }
 
+func (c *Client) setCurrentStep(s *types.Step, phase types.StepPhase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.currentStep = s
+	c.currentPhase = phase
+}
+
+// getCurrentStep returns the step which was running or finished lately, and its phase
+func (c *Client) getCurrentStep() (*types.Step, types.StepPhase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.currentStep, c.currentPhase
+}
+
 func (c *Client) updateStepInformationToScheduler(s *types.Step) (err error) {
 	s, err = c.runner.Step(s)
 	if err != nil {
@@ -232,3 +293,93 @@ func (c *Client) updateStepInformationToScheduler(s *types.Step) (err error) {
 	c.writeChan <- data
 	return nil
 }
+
+const (
+	ErrClientWasDraining = "the Runner was draining, the step was refused"
+)
+
+// refuseStep reports the step as failed to the Scheduler, because the Client was draining.
+func (c *Client) refuseStep(s *types.Step) {
+	klog.Infof("Client refused step:%s because it was draining", s.Name)
+	t, err := c.runner.Step(s)
+	if err != nil {
+		klog.V(2).Info(err)
+		return
+	}
+	t.Phase = types.StepFailed
+	t.Messages = append(t.Messages, types.StepMessage(t.Name, ErrClientWasDraining))
+	if err = c.updateStepInformationToScheduler(s); err != nil {
+		klog.V(2).Info(err)
+	}
+}
+
+// Done returns a channel which would be closed after the Client was closed
+func (c *Client) Done() <-chan struct{} {
+	return c.ctx.Done()
+}
+
+// Shutdown closes the Client. If drain was true, the Client refuses the new steps and waits for the
+// current step until it was finished or the ctx was done, and then the pending messages would be sent
+// before the websocket connection was closed. The step which was not drained would be cancelled.
+func (c *Client) Shutdown(ctx context.Context, drain bool) (err error) {
+	atomic.StoreInt32(&c.draining, 1)
+	if drain {
+		klog.Info("Client was draining")
+		done := make(chan struct{})
+		go func() {
+			c.running.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		// the nil was queued after the pending messages, so that they would have been sent once the flushed was closed
+		select {
+		case c.writeChan <- nil:
+			select {
+			case <-c.flushed:
+			case <-c.ctx.Done():
+			case <-ctx.Done():
+			}
+		case <-c.ctx.Done():
+		case <-ctx.Done():
+		}
+	}
+	// the current step would be cancelled if it was not drained
+	if !drain || err != nil {
+		c.runner.Cancel()
+	}
+	atomic.StoreInt32(&c.closed, 1)
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "runner was shutting down")
+	if e := c.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second)); e != nil {
+		klog.V(2).Info(e)
+	}
+	c.close()
+	return err
+}
+
+// ShutdownOnSignal blocks until the stopCh or the Client was closed, and then shuts the Client down with the
+// timeout. The stopCh was usually returned by the signals.SetupSignalHandler, so that the SIGTERM drains the Client.
+func (c *Client) ShutdownOnSignal(stopCh <-chan struct{}, drain bool, timeout time.Duration) error {
+	select {
+	case <-stopCh:
+	case <-c.Done():
+		return nil
+	}
+	klog.Info("Client received the shutdown signal")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return c.Shutdown(ctx, drain)
+}
+
+func (c *Client) close() {
+	c.closeOnce.Do(func() {
+		atomic.StoreInt32(&c.connected, 0)
+		c.cancel()
+		if err := c.conn.Close(); err != nil {
+			klog.V(2).Info(err)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/gorilla/websocket"
+)
+
+// blockingOperator was a StepOperator which would be running until the release was closed
+type blockingOperator struct {
+	step    *types.Step
+	started chan struct{}
+	release chan struct{}
+	runs    int32
+}
+
+func newBlockingOperator(name string) *blockingOperator {
+	return &blockingOperator{
+		step:    &types.Step{Name: name, Phase: types.StepPending, Envs: map[string]string{}},
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+}
+
+func (b *blockingOperator) Step() *types.Step {
+	return b.step
+}
+
+func (b *blockingOperator) Update(s *types.Step) {
+	b.step = s.DeepCopy()
+}
+
+func (b *blockingOperator) Prepare() {
+	b.step.Messages = make([]string, 0)
+}
+
+func (b *blockingOperator) Run(output chan<- string) ([]string, error) {
+	atomic.AddInt32(&b.runs, 1)
+	b.step.Phase = types.StepRunning
+	b.started <- struct{}{}
+	<-b.release
+	b.step.Phase = types.StepSucceeded
+	return nil, nil
+}
+
+// fakeScheduler accepts one Runner, and it collects the steps which were reported by the UpdateStep
+type fakeScheduler struct {
+	server  *httptest.Server
+	conn    chan *websocket.Conn
+	updates chan types.Step
+}
+
+func newFakeScheduler(t *testing.T) *fakeScheduler {
+	f := &fakeScheduler{
+		conn:    make(chan *websocket.Conn, 1),
+		updates: make(chan types.Step, 10),
+	}
+	upGrader := websocket.Upgrader{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upGrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		f.conn <- c
+		for {
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := &types.Request{}
+			if err = req.Unmarshal(message); err != nil {
+				t.Error(err)
+				return
+			}
+			if req.Type.ServiceAPI != types.UpdateStep {
+				continue
+			}
+			data := &types.UpdateStepRequest{}
+			if err = data.Unmarshal(req.Data); err != nil {
+				t.Error(err)
+				return
+			}
+			f.updates <- data.Step
+		}
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeScheduler) runStep(t *testing.T, c *websocket.Conn, name string) {
+	data, err := (&types.RunStepRequest{Step: types.Step{Name: name}}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := (&types.Request{Type: types.Type{Body: types.BodyRunner, ServiceAPI: types.RunStep}, Data: data}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fakeScheduler) waitForUpdate(t *testing.T) types.Step {
+	select {
+	case s := <-f.updates:
+		return s
+	case <-time.After(time.Second * 5):
+		t.Fatal("the UpdateStep was not received")
+	}
+	return types.Step{}
+}
+
+func Test_Client_Shutdown(t *testing.T) {
+	scheduler := newFakeScheduler(t)
+	slow, fast := newBlockingOperator("Slow"), newBlockingOperator("Fast")
+	r := &Runner{Name: "runner1", Namespace: "ns1", GroupName: "group1"}
+	r.StepOperators = append(r.StepOperators, slow, fast)
+	c, err := NewClient(strings.TrimPrefix(scheduler.server.URL, "http://"), make(chan string, 100), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := <-scheduler.conn
+	scheduler.runStep(t, conn, "Slow")
+	<-slow.started
+
+	done := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		done <- c.Shutdown(ctx, true)
+	}()
+	for atomic.LoadInt32(&c.draining) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the new step would be refused while draining
+	scheduler.runStep(t, conn, "Fast")
+	refused := scheduler.waitForUpdate(t)
+	if refused.Name != "Fast" || refused.Phase != types.StepFailed {
+		t.Errorf("refused step = %s %s, want Fast %s", refused.Name, refused.Phase, types.StepFailed)
+	}
+	if len(refused.Messages) == 0 || !strings.Contains(refused.Messages[len(refused.Messages)-1], ErrClientWasDraining) {
+		t.Errorf("refused step Messages = %v, want %s", refused.Messages, ErrClientWasDraining)
+	}
+	if n := atomic.LoadInt32(&fast.runs); n != 0 {
+		t.Errorf("refused step runs = %d, want 0", n)
+	}
+
+	// the running step would be waited for
+	select {
+	case err = <-done:
+		t.Fatalf("Shutdown() returned before the running step was finished, err = %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+	close(slow.release)
+	finished := scheduler.waitForUpdate(t)
+	if finished.Name != "Slow" || finished.Phase != types.StepSucceeded {
+		t.Errorf("finished step = %s %s, want Slow %s", finished.Name, finished.Phase, types.StepSucceeded)
+	}
+	if err = <-done; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	select {
+	case <-c.Done():
+	default:
+		t.Error("Shutdown() the Client was not closed")
+	}
+}
+
+func Test_Client_ShutdownOnSignal(t *testing.T) {
+	scheduler := newFakeScheduler(t)
+	r := &Runner{Name: "runner1", Namespace: "ns1", GroupName: "group1"}
+	c, err := NewClient(strings.TrimPrefix(scheduler.server.URL, "http://"), make(chan string, 100), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopCh := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- c.ShutdownOnSignal(stopCh, true, time.Second)
+	}()
+	close(stopCh)
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("ShutdownOnSignal() error = %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("ShutdownOnSignal() was not returned")
+	}
+	if atomic.LoadInt32(&c.draining) != 1 {
+		t.Error("ShutdownOnSignal() the Client was not draining")
+	}
+}
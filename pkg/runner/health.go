@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/health"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ErrWebsocketWasDisconnected = "error: the websocket connection to the Scheduler was disconnected"
+)
+
+// ServeHealth exposes the liveness and readiness probes of the Client on the local addr.
+// The readiness probe reports the websocket state and the current step.
+func (c *Client) ServeHealth(addr string) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	go func() {
+		if err := http.Serve(l, c.healthHandler()); err != nil {
+			klog.V(2).Info(err)
+		}
+	}()
+}
+
+func (c *Client) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(types.HttpHandlerHealthz, health.LivenessHandler())
+	mux.Handle(types.HttpHandlerReadyz, health.ReadinessHandler(c.readinessChecks(), c.healthInfo))
+	return mux
+}
+
+func (c *Client) readinessChecks() []health.Check {
+	return []health.Check{
+		{
+			Name: "websocket",
+			Func: func(ctx context.Context) error {
+				if atomic.LoadInt32(&c.connected) == 0 {
+					return errors.New(ErrWebsocketWasDisconnected)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+func (c *Client) healthInfo() map[string]string {
+	info := map[string]string{
+		"namespace": string(c.runner.Namespace),
+		"groupName": string(c.runner.GroupName),
+		"runner":    c.runner.Name,
+	}
+	if atomic.LoadInt32(&c.connected) == 1 {
+		info["websocket"] = "connected"
+	} else {
+		info["websocket"] = "disconnected"
+	}
+	if s, phase := c.getCurrentStep(); s != nil {
+		info["currentStep"] = s.Name
+		info["phase"] = string(phase)
+	}
+	return info
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/health"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_Client_healthHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		connected  int32
+		step       *types.Step
+		phase      types.StepPhase
+		wantCode   int
+		wantStatus string
+		wantInfo   map[string]string
+	}{
+		{
+			name:       "Test_Client_healthHandler_1",
+			path:       types.HttpHandlerHealthz,
+			wantCode:   http.StatusOK,
+			wantStatus: health.StatusOK,
+		},
+		{
+			name:       "Test_Client_healthHandler_2",
+			path:       types.HttpHandlerReadyz,
+			connected:  1,
+			step:       &types.Step{Name: "Robot"},
+			phase:      types.StepRunning,
+			wantCode:   http.StatusOK,
+			wantStatus: health.StatusOK,
+			wantInfo: map[string]string{
+				"namespace":   "ns1",
+				"groupName":   "group1",
+				"runner":      "runner1",
+				"websocket":   "connected",
+				"currentStep": "Robot",
+				"phase":       string(types.StepRunning),
+			},
+		},
+		{
+			name:       "Test_Client_healthHandler_3",
+			path:       types.HttpHandlerReadyz,
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusFailed,
+			wantInfo: map[string]string{
+				"namespace": "ns1",
+				"groupName": "group1",
+				"runner":    "runner1",
+				"websocket": "disconnected",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{
+				connected: tt.connected,
+				runner:    &Runner{Name: "runner1", Namespace: "ns1", GroupName: "group1"},
+			}
+			if tt.step != nil {
+				c.setCurrentStep(tt.step, tt.phase)
+			}
+			server := httptest.NewServer(c.healthHandler())
+			defer server.Close()
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("healthHandler() code = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			s := &health.Status{}
+			if err = json.NewDecoder(resp.Body).Decode(s); err != nil {
+				t.Fatal(err)
+			}
+			if s.Status != tt.wantStatus {
+				t.Errorf("healthHandler() status = %v, want %v", s.Status, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(s.Info, tt.wantInfo) {
+				t.Errorf("healthHandler() info = %v, want %v", s.Info, tt.wantInfo)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package runner
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
 	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/Shanghai-Lunara/publisher/pkg/utils/operators"
 	"k8s.io/klog/v2"
 	"time"
 )
@@ -45,19 +47,27 @@ func (r *Runner) Run(s *types.Step) (err error) {
 			v.Update(s)
 			exist = true
 			start := time.Now()
-			s.DurationInMS = 0
+			v.Step().DurationInMS = 0
 			v.Prepare()
 			res, err := v.Run(r.StreamOutput)
+			// the failed Step also reports its duration
+			v.Step().DurationInMS = int32(time.Now().Sub(start).Milliseconds())
 			if err != nil {
 				klog.V(2).Info(err)
 				r.StreamOutput <- err.Error()
 				if len(v.Step().Messages) == 0 {
 					v.Step().Messages = make([]string, 0)
 				}
-				v.Step().Messages = append(v.Step().Messages, err.Error())
+				// the exit code and the last stderr lines of the failed command were split into the Messages,
+				// instead of the err whose Error() had already contained the stderr
+				var e *operators.ExecError
+				if errors.As(err, &e) {
+					v.Step().Messages = append(v.Step().Messages, e.Messages()...)
+				} else {
+					v.Step().Messages = append(v.Step().Messages, err.Error())
+				}
 				return err
 			}
-			v.Step().DurationInMS = int32(time.Now().Sub(start).Milliseconds())
 			_ = res
 		}
 	}
@@ -68,6 +78,15 @@ func (r *Runner) Run(s *types.Step) (err error) {
 	return nil
 }
 
+// Cancel cancels the running Steps whose StepOperators implemented the interfaces.Canceler
+func (r *Runner) Cancel() {
+	for _, v := range r.StepOperators {
+		if c, ok := v.(interfaces.Canceler); ok {
+			c.Cancel()
+		}
+	}
+}
+
 func (r *Runner) Update(s *types.Step) (err error) {
 	exist := false
 	for _, v := range r.StepOperators {
@@ -0,0 +1,63 @@
+package runner
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/utils/operators"
+)
+
+func Test_Runner_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantMessages []string
+		wantErr      bool
+	}{
+		{
+			name:         "Test_Runner_Run_1",
+			body:         "echo built\n",
+			wantMessages: []string{},
+			wantErr:      false,
+		},
+		{
+			name:         "Test_Runner_Run_2",
+			body:         "echo 'fatal: not found' >&2\nexit 3\n",
+			wantMessages: []string{"exit code: 3", "fatal: not found"},
+			wantErr:      true,
+		},
+		{
+			name:         "Test_Runner_Run_3",
+			body:         "",
+			wantMessages: []string{operators.ErrScriptEmpty},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := operators.NewScript("Build", tt.body, t.TempDir())
+			output := make(chan string, 4096)
+			r := &Runner{
+				Name:          "runner",
+				StepOperators: []interfaces.StepOperator{op},
+				StreamOutput:  output,
+			}
+			if err := r.Run(op.Step().DeepCopy()); (err != nil) != tt.wantErr {
+				t.Fatalf("Runner.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// the messages of the error were appended after the ones of the operator
+			messages := op.Step().Messages
+			got := make([]string, 0)
+			for _, v := range messages {
+				if !strings.HasSuffix(v, "is starting") {
+					got = append(got, v)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("Runner.Run() Messages = %v, want %v", messages, tt.wantMessages)
+			}
+		})
+	}
+}
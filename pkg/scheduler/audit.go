@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// AuditActorHeader was the header which would be set by an authenticating reverse proxy
+	AuditActorHeader = "X-Forwarded-User"
+
+	ErrTrustedProxyInvalid = "error: the trusted proxy:%s was neither an ip nor a cidr"
+
+	auditMask = "******"
+)
+
+// identity was the actor and the source ip of a connection
+type identity struct {
+	actor    string
+	sourceIP string
+}
+
+// trustedProxies were the networks of the reverse proxies whose forwarded headers would be trusted
+type trustedProxies []*net.IPNet
+
+func newTrustedProxies(items []string) (trustedProxies, error) {
+	res := make(trustedProxies, 0, len(items))
+	for _, v := range items {
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, fmt.Errorf(ErrTrustedProxyInvalid, v)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				bits = 8 * net.IPv4len
+			}
+			res = append(res, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf(ErrTrustedProxyInvalid, v)
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+func (tp trustedProxies) contains(addr string) bool {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return false
+	}
+	for _, v := range tp {
+		if v.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// newIdentity trusts the AuditActorHeader and the X-Forwarded-For only if the request came from a trusted proxy,
+// because they could be forged by any client. Otherwise the actor was unknown and the source ip was the RemoteAddr.
+func newIdentity(r *http.Request, tp trustedProxies) *identity {
+	id := &identity{
+		sourceIP: r.RemoteAddr,
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		id.sourceIP = host
+	}
+	if !tp.contains(id.sourceIP) {
+		return id
+	}
+	id.actor = r.Header.Get(AuditActorHeader)
+	// the client was the last address which was not a trusted proxy, because the earlier ones could be forged
+	if t := r.Header.Get("X-Forwarded-For"); t != "" {
+		items := strings.Split(t, ",")
+		for i := len(items) - 1; i >= 0; i-- {
+			v := strings.TrimSpace(items[i])
+			if v == "" {
+				continue
+			}
+			id.sourceIP = v
+			if !tp.contains(v) {
+				break
+			}
+		}
+	} else if t := r.Header.Get("X-Real-IP"); t != "" {
+		id.sourceIP = strings.TrimSpace(t)
+	}
+	return id
+}
+
+// withTriggerUser sets the actor into the Envs of the Step which would be sent to the Runner as the
+// PUBLISHER_TRIGGER_USER, so that the operators could reference the dashboard user who triggered it, such as in
+// the git commit message. It would be removed if there was no actor, so that the user of a former run was never kept.
+func withTriggerUser(step *types.Step, id *identity) {
+	if id == nil || id.actor == "" {
+		delete(step.Envs, types.PublisherTriggerUser)
+		return
+	}
+	if step.Envs == nil {
+		step.Envs = make(map[string]string, 0)
+	}
+	step.Envs[types.PublisherTriggerUser] = id.actor
+}
+
+// newAudit creates the Audit of a RunStep or UpdateStep request before it was handled,
+// and the Changes would be computed against the current Step in the Scheduler.
+func (s *Scheduler) newAudit(api types.ServiceAPI, data []byte, id *identity) *types.Audit {
+	req := &types.RunStepRequest{}
+	if err := req.Unmarshal(data); err != nil {
+		klog.V(2).Info(err)
+		return nil
+	}
+	a := &types.Audit{
+		ServiceAPI: api,
+		Namespace:  req.Namespace,
+		GroupName:  req.GroupName,
+		RunnerName: req.RunnerName,
+		StepName:   req.Step.Name,
+		Changes:    make([]types.AuditChange, 0),
+		CreatedTM:  int32(time.Now().Unix()),
+	}
+	if id != nil {
+		a.Actor = id.actor
+		a.SourceIP = id.sourceIP
+	}
+	g, err := s.getGroup(req.Namespace, req.GroupName)
+	if err != nil {
+		return a
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ri, ok := g.Runners[req.RunnerName]; ok {
+		for _, v := range ri.Steps {
+			if v.Name == req.Step.Name {
+				a.Changes = diffStep(&v, &req.Step)
+				break
+			}
+		}
+	}
+	return a
+}
+
+// goRecordAudit writes the Audit into the db in a new goroutine if the action was handled successfully
+func (s *Scheduler) goRecordAudit(a *types.Audit, err error) {
+	if a == nil || err != nil {
+		return
+	}
+	s.records.Add(1)
+	go func() {
+		defer s.records.Done()
+		if err := s.dao.InsertAudit(a); err != nil {
+			klog.V(2).Info(err)
+			recordAuditErrorsTotal.Inc()
+		}
+	}()
+}
+
+// diffStep compares the Envs, Policy and Available, the values of the secret Envs would be masked
+func diffStep(before, after *types.Step) []types.AuditChange {
+	res := make([]types.AuditChange, 0)
+	if before.Policy != after.Policy {
+		res = append(res, types.AuditChange{Field: "policy", Before: string(before.Policy), After: string(after.Policy)})
+	}
+	if before.Available != after.Available {
+		res = append(res, types.AuditChange{Field: "available", Before: string(before.Available), After: string(after.Available)})
+	}
+	keys := make([]string, 0)
+	for k := range before.Envs {
+		keys = append(keys, k)
+	}
+	for k := range after.Envs {
+		if _, ok := before.Envs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// the trigger user was set by the Scheduler on each RunStep, it was the Actor rather than a change
+		if k == types.PublisherTriggerUser {
+			continue
+		}
+		b, ok1 := before.Envs[k]
+		t, ok2 := after.Envs[k]
+		if ok1 == ok2 && b == t {
+			continue
+		}
+		if isSecretEnv(k) {
+			if ok1 {
+				b = auditMask
+			}
+			if ok2 {
+				t = auditMask
+			}
+		}
+		res = append(res, types.AuditChange{Field: "envs." + k, Before: b, After: t})
+	}
+	return res
+}
+
+func isSecretEnv(key string) bool {
+	k := strings.ToLower(key)
+	for _, v := range []string{"password", "secret", "token"} {
+		if strings.Contains(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scheduler) handleListAuditsRequest(data []byte) (res []byte, err error) {
+	req := &types.ListAuditsRequest{}
+	if err = req.Unmarshal(data); err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	audits, num, err := s.dao.ListAudits(req)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	response := &types.ListAuditsResponse{
+		Params:      *req,
+		Audits:      audits,
+		AuditNumber: num,
+	}
+	return response.Marshal()
+}
@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_diffStep(t *testing.T) {
+	type args struct {
+		before *types.Step
+		after  *types.Step
+	}
+	tests := []struct {
+		name string
+		args args
+		want []types.AuditChange
+	}{
+		{
+			name: "Test_diffStep_1",
+			args: args{
+				before: &types.Step{Policy: types.StepPolicyAuto, Available: types.StepAvailableEnable, Envs: map[string]string{"a": "1"}},
+				after:  &types.Step{Policy: types.StepPolicyAuto, Available: types.StepAvailableEnable, Envs: map[string]string{"a": "1"}},
+			},
+			want: []types.AuditChange{},
+		},
+		{
+			name: "Test_diffStep_2",
+			args: args{
+				before: &types.Step{
+					Policy:    types.StepPolicyAuto,
+					Available: types.StepAvailableEnable,
+					Envs:      map[string]string{"a": "1", "b": "2", types.PublisherFtpPassword: "x"},
+				},
+				after: &types.Step{
+					Policy:    types.StepPolicyManual,
+					Available: types.StepAvailableDisable,
+					Envs:      map[string]string{"a": "3", "c": "4", types.PublisherFtpPassword: "y"},
+				},
+			},
+			want: []types.AuditChange{
+				{Field: "policy", Before: "auto", After: "manual"},
+				{Field: "available", Before: "enable", After: "disable"},
+				{Field: "envs.a", Before: "1", After: "3"},
+				{Field: "envs.b", Before: "2", After: ""},
+				{Field: "envs.c", Before: "", After: "4"},
+				{Field: "envs.ftp_password", Before: auditMask, After: auditMask},
+			},
+		},
+		{
+			name: "Test_diffStep_3",
+			args: args{
+				before: &types.Step{Envs: map[string]string{types.PublisherTriggerUser: "alice"}},
+				after:  &types.Step{Envs: map[string]string{types.PublisherTriggerUser: "bob"}},
+			},
+			want: []types.AuditChange{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffStep(tt.args.before, tt.args.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffStep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_withTriggerUser(t *testing.T) {
+	tests := []struct {
+		name string
+		envs map[string]string
+		id   *identity
+		want map[string]string
+	}{
+		{
+			name: "Test_withTriggerUser_1",
+			envs: map[string]string{"a": "1"},
+			id:   &identity{actor: "alice", sourceIP: "127.0.0.1"},
+			want: map[string]string{"a": "1", types.PublisherTriggerUser: "alice"},
+		},
+		{
+			name: "Test_withTriggerUser_2",
+			envs: map[string]string{"a": "1", types.PublisherTriggerUser: "alice"},
+			id:   &identity{sourceIP: "127.0.0.1"},
+			want: map[string]string{"a": "1"},
+		},
+		{
+			name: "Test_withTriggerUser_3",
+			envs: map[string]string{"a": "1", types.PublisherTriggerUser: "alice"},
+			id:   nil,
+			want: map[string]string{"a": "1"},
+		},
+		{
+			name: "Test_withTriggerUser_4",
+			envs: nil,
+			id:   &identity{actor: "bob"},
+			want: map[string]string{types.PublisherTriggerUser: "bob"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &types.Step{Name: "s", Envs: tt.envs}
+			withTriggerUser(step, tt.id)
+			if !reflect.DeepEqual(step.Envs, tt.want) {
+				t.Errorf("withTriggerUser() Envs = %v, want %v", step.Envs, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newIdentity(t *testing.T) {
+	tp, err := newTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       *identity
+	}{
+		{
+			name:       "Test_newIdentity_1",
+			remoteAddr: "203.0.113.9:50000",
+			headers:    map[string]string{AuditActorHeader: "alice", "X-Forwarded-For": "198.51.100.1"},
+			want:       &identity{sourceIP: "203.0.113.9"},
+		},
+		{
+			name:       "Test_newIdentity_2",
+			remoteAddr: "10.1.2.3:50000",
+			headers:    map[string]string{AuditActorHeader: "alice", "X-Forwarded-For": "198.51.100.1, 198.51.100.2, 10.9.9.9"},
+			want:       &identity{actor: "alice", sourceIP: "198.51.100.2"},
+		},
+		{
+			name:       "Test_newIdentity_3",
+			remoteAddr: "192.168.1.1:50000",
+			headers:    map[string]string{AuditActorHeader: "bob", "X-Real-IP": "198.51.100.3"},
+			want:       &identity{actor: "bob", sourceIP: "198.51.100.3"},
+		},
+		{
+			name:       "Test_newIdentity_4",
+			remoteAddr: "192.168.1.2:50000",
+			headers:    map[string]string{AuditActorHeader: "bob"},
+			want:       &identity{sourceIP: "192.168.1.2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?user=mallory", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if got := newIdentity(r, tp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newIdentity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTrustedProxies(t *testing.T) {
+	if _, err := newTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("newTrustedProxies() error = nil, want an invalid cidr error")
+	}
+	if _, err := newTrustedProxies([]string{"proxy.local"}); err == nil {
+		t.Error("newTrustedProxies() error = nil, want an invalid ip error")
+	}
+}
@@ -26,7 +26,12 @@ var upGrader = websocket.Upgrader{
 }
 
 func NewConnections(ctx context.Context, c *conf.Config) *connections {
+	tp, err := newTrustedProxies(c.PublisherService.TrustedProxies)
+	if err != nil {
+		klog.Fatal(err)
+	}
 	cs := &connections{
+		trustedProxies:  tp,
 		autoIncrementId: 0,
 		items:           make(map[int32]*conn, 0),
 		broadcast:       make(chan *broadcast, 1024),
@@ -47,6 +52,9 @@ type connections struct {
 	removedChan     chan int32
 	scheduler       *Scheduler
 	ctx             context.Context
+	// broadcasterAlive would be 1 while the broadcastToDashboard goroutine was running
+	broadcasterAlive int32
+	trustedProxies   trustedProxies
 }
 
 type broadcastType string
@@ -66,6 +74,8 @@ type broadcast struct {
 }
 
 func (cs *connections) broadcastToDashboard() {
+	atomic.StoreInt32(&cs.broadcasterAlive, 1)
+	defer atomic.StoreInt32(&cs.broadcasterAlive, 0)
 	for {
 		select {
 		case broadcast, isClose := <-cs.broadcast:
@@ -112,6 +122,44 @@ func (cs *connections) broadcastToDashboard() {
 	}
 }
 
+// shutdown notifies all the dashboards and Runners that the Scheduler was shutting down,
+// and then it closes all the connections after their pending messages have been sent or the ctx was done.
+func (cs *connections) shutdown(ctx context.Context) {
+	cs.scheduler.setClosing()
+	req := &types.Request{
+		Type: types.Type{
+			ServiceAPI: types.Shutdown,
+		},
+	}
+	msg, err := req.Marshal()
+	if err != nil {
+		klog.V(2).Info(err)
+	}
+	cs.mu.RLock()
+	items := make([]*conn, 0, len(cs.items))
+	for _, v := range cs.items {
+		items = append(items, v)
+	}
+	cs.mu.RUnlock()
+	for _, v := range items {
+		// the nil message asks the writePump to send the close frame after the pending messages
+		for _, m := range [][]byte{msg, nil} {
+			select {
+			case v.writeChan <- m:
+			case <-v.ctx.Done():
+			case <-ctx.Done():
+			}
+		}
+	}
+	for _, v := range items {
+		select {
+		case <-v.ctx.Done():
+		case <-ctx.Done():
+		}
+		v.close()
+	}
+}
+
 func (cs *connections) remove() {
 	for {
 		select {
@@ -160,6 +208,7 @@ func (cs *connections) newConn(w http.ResponseWriter, r *http.Request, body type
 	c := &conn{
 		scheduler:             cs.scheduler,
 		body:                  body,
+		identity:              newIdentity(r, cs.trustedProxies),
 		id:                    atomic.AddInt32(&cs.autoIncrementId, 1),
 		conn:                  client,
 		writeChan:             make(chan []byte, 4096),
@@ -180,6 +229,7 @@ func (cs *connections) newConn(w http.ResponseWriter, r *http.Request, body type
 type conn struct {
 	scheduler             *Scheduler
 	body                  types.Body
+	identity              *identity
 	id                    int32
 	runnerName            string
 	conn                  *websocket.Conn
@@ -234,7 +284,7 @@ func (c *conn) readPump() {
 			klog.V(2).Info(err)
 			return
 		}
-		res, err := c.scheduler.handle(data, c.id)
+		res, err := c.scheduler.handle(data, c.id, c.identity)
 		if err != nil {
 			return
 		}
@@ -252,6 +302,13 @@ func (c *conn) writePump() {
 			if !isClose {
 				return
 			}
+			if msg == nil {
+				closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "scheduler was shutting down")
+				if err := c.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second)); err != nil {
+					klog.V(2).Info(err)
+				}
+				return
+			}
 			if err := c.conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
 				klog.V(2).Info(err)
 				return
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/health"
+)
+
+const (
+	ErrConfigWasNotLoaded     = "error: the configuration was not loaded"
+	ErrNoProjectsConfigured   = "error: there were no projects in the configuration"
+	ErrBroadcasterWasNotAlive = "error: the broadcaster goroutine was not running"
+)
+
+func (s *Server) readinessChecks() []health.Check {
+	return []health.Check{
+		{
+			Name: "database",
+			Func: func(ctx context.Context) error {
+				return s.connections.scheduler.dao.Mysql.Ping(ctx)
+			},
+		},
+		{
+			Name: "broadcaster",
+			Func: func(ctx context.Context) error {
+				if atomic.LoadInt32(&s.connections.broadcasterAlive) == 0 {
+					return errors.New(ErrBroadcasterWasNotAlive)
+				}
+				return nil
+			},
+		},
+		{
+			Name: "config",
+			Func: func(ctx context.Context) error {
+				if s.conf == nil {
+					return errors.New(ErrConfigWasNotLoaded)
+				}
+				if len(s.conf.Projects) == 0 {
+					return errors.New(ErrNoProjectsConfigured)
+				}
+				return nil
+			},
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "publisher"
+	metricsSubsystem = "scheduler"
+)
+
+var (
+	stepRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "step_runs_total",
+		Help:      "Number of the finished steps which were reported by the Runners, labeled by the terminal phase.",
+	}, []string{"namespace", "group", "step", "phase"})
+
+	stepDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "step_duration_seconds",
+		Help:      "Duration of the finished steps which were reported by the Runners.",
+		// from 1s to about 4.5h
+		Buckets: prometheus.ExponentialBuckets(1, 2, 15),
+	}, []string{"namespace", "group", "step", "phase"})
+
+	recordStepErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "record_step_errors_total",
+		Help:      "Number of failures while writing step records into the database.",
+	})
+
+	recordAuditErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "record_audit_errors_total",
+		Help:      "Number of failures while writing audits into the database.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(stepRunsTotal, stepDurationSeconds, recordStepErrorsTotal, recordAuditErrorsTotal)
+}
+
+// observeStep counts the finished Step and observes its duration, the other phases were ignored.
+func observeStep(namespace types.Namespace, groupName types.GroupName, step *types.Step) {
+	switch step.Phase {
+	case types.StepSucceeded, types.StepFailed:
+	default:
+		return
+	}
+	stepRunsTotal.WithLabelValues(string(namespace), string(groupName), step.Name, string(step.Phase)).Inc()
+	d := time.Duration(step.DurationInMS) * time.Millisecond
+	stepDurationSeconds.WithLabelValues(string(namespace), string(groupName), step.Name, string(step.Phase)).Observe(d.Seconds())
+}
+
+var (
+	connectedRunnersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "connected_runners"),
+		"Number of the registered Runners.",
+		[]string{"namespace", "group"}, nil)
+	connectedDashboardsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "connected_dashboards"),
+		"Number of the connected web dashboards.",
+		nil, nil)
+	broadcastQueueDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "broadcast_queue_length"),
+		"Number of the messages waiting in the broadcast channel.",
+		nil, nil)
+	connWriteQueueDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "conn_write_queue_length"),
+		"Number of the messages waiting in the writeChans of the connections, summed by the body and the runner.",
+		[]string{"body", "runner"}, nil)
+)
+
+// metricsCollector implements prometheus.Collector, and it reads the runtime states of the connections
+// and the Scheduler at the time of each scrape.
+type metricsCollector struct {
+	cs *connections
+}
+
+func newMetricsCollector(cs *connections) *metricsCollector {
+	return &metricsCollector{cs: cs}
+}
+
+func (mc *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- connectedRunnersDesc
+	ch <- connectedDashboardsDesc
+	ch <- broadcastQueueDesc
+	ch <- connWriteQueueDesc
+}
+
+func (mc *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := mc.cs.scheduler
+	s.mu.Lock()
+	for ns, v := range s.items {
+		for gn, v2 := range v.items {
+			ch <- prometheus.MustNewConstMetric(connectedRunnersDesc, prometheus.GaugeValue, float64(len(v2.Runners)), string(ns), string(gn))
+		}
+	}
+	s.mu.Unlock()
+	dashboards := 0
+	// the connection ids were not used as the labels, because each reconnection would create a new series
+	type queueKey struct {
+		body   types.Body
+		runner string
+	}
+	queues := make(map[queueKey]int, 0)
+	mc.cs.mu.RLock()
+	for _, v := range mc.cs.items {
+		if v.body == types.BodyDashboard {
+			dashboards++
+		}
+		queues[queueKey{body: v.body, runner: v.runnerName}] += len(v.writeChan)
+	}
+	mc.cs.mu.RUnlock()
+	for k, v := range queues {
+		ch <- prometheus.MustNewConstMetric(connWriteQueueDesc, prometheus.GaugeValue, float64(v), string(k.body), k.runner)
+	}
+	ch <- prometheus.MustNewConstMetric(connectedDashboardsDesc, prometheus.GaugeValue, float64(dashboards))
+	ch <- prometheus.MustNewConstMetric(broadcastQueueDesc, prometheus.GaugeValue, float64(len(mc.cs.broadcast)))
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ErrRecordWasNotMatched   = "error: the record id:%d was not belonged to namespace:%s groupName:%s"
+	ErrRecordWasNotVersion   = "error: the record id:%d was not a version record"
+	ErrRecordWasNotSucceeded = "error: the record id:%d was not succeeded, phase:%s"
+	ErrRecordWasNotRelease   = "error: the record id:%d was not published into a release directory, only the ftp releases could be rolled back"
+)
+
+func (s *Scheduler) handleListRollbackVersionsRequest(data []byte) (res []byte, err error) {
+	req := &types.ListRecordsRequest{}
+	if err = req.Unmarshal(data); err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	req.IsVersion = types.RecordVersion
+	records, num, err := s.dao.ListSucceededVersions(req)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	response := &types.ListRecordsResponse{
+		Params:       *req,
+		Records:      records,
+		RecordNumber: num,
+	}
+	return response.Marshal()
+}
+
+// handleRollbackRequest runs the recorded Step again with the rollback Envs, and the ftp operator would re-point the
+// latest release to the recorded one. Only the records which had been published into a release directory could be
+// rolled back, because the other operators would publish the current source again. The rollback would be recorded
+// as its own version record when it was completed, which links to the original one by the PUBLISHER_ROLLBACK_RECORD_ID,
+// and it would not trigger the automatic downstream steps.
+func (s *Scheduler) handleRollbackRequest(data []byte, id *identity) (res []byte, err error) {
+	req := &types.RollbackRequest{}
+	if err = req.Unmarshal(data); err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	record, err := s.dao.GetRecord(req.RecordId)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	step, err := newRollbackStep(req, record)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	if req.RunnerName == "" {
+		req.RunnerName = record.RunnerName
+	}
+	runStep := &types.RunStepRequest{
+		Namespace:  req.Namespace,
+		GroupName:  req.GroupName,
+		RunnerName: req.RunnerName,
+		Step:       *step,
+	}
+	if data, err = runStep.Marshal(); err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	a := s.newAudit(types.ServiceAPIRollbackRequest, data, id)
+	_, err = s.handleRunStep(data, id)
+	s.goRecordAudit(a, err)
+	if err != nil {
+		klog.V(2).Info(err)
+		return nil, err
+	}
+	response := &types.RollbackResponse{
+		Params: *req,
+		Step:   *step,
+	}
+	return response.Marshal()
+}
+
+// newRollbackStep decodes the recorded Step and sets the rollback Envs
+func newRollbackStep(req *types.RollbackRequest, record *types.Record) (*types.Step, error) {
+	if record.Namespace != req.Namespace || record.GroupName != req.GroupName {
+		return nil, fmt.Errorf(ErrRecordWasNotMatched, record.Id, req.Namespace, req.GroupName)
+	}
+	if record.StepType != types.RecordVersion {
+		return nil, fmt.Errorf(ErrRecordWasNotVersion, record.Id)
+	}
+	step := &types.Step{}
+	if err := step.Unmarshal(record.StepInfo); err != nil {
+		return nil, err
+	}
+	if step.Phase != types.StepSucceeded {
+		return nil, fmt.Errorf(ErrRecordWasNotSucceeded, record.Id, step.Phase)
+	}
+	// the release directory was only published by the ftp operator which reads the rollback Envs
+	dir := step.SharingData[types.PublisherReleaseDir]
+	if dir == "" {
+		return nil, fmt.Errorf(ErrRecordWasNotRelease, record.Id)
+	}
+	if step.Envs == nil {
+		step.Envs = make(map[string]string, 0)
+	}
+	step.Envs[types.PublisherRollbackRecordId] = strconv.Itoa(int(record.Id))
+	step.Envs[types.PublisherRollbackReleaseDir] = dir
+	step.Phase = types.StepPending
+	step.Messages = make([]string, 0)
+	step.Output = make([]string, 0)
+	step.Remarks = make([]string, 0)
+	return step, nil
+}
+
+// isRollbackStep returns whether the Step reported by the Runner was a rollback,
+// the ftp operator shares the PUBLISHER_ROLLBACK_RECORD_ID only when it rolled back
+func isRollbackStep(step *types.Step) bool {
+	return step.SharingData[types.PublisherRollbackRecordId] != ""
+}
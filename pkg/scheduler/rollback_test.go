@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_newRollbackStep(t *testing.T) {
+	newRecord := func(id int32, stepType int32, step *types.Step) *types.Record {
+		data, err := step.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &types.Record{
+			Id:         id,
+			Namespace:  "game",
+			GroupName:  "data",
+			RunnerName: "ftp-runner",
+			StepInfo:   data,
+			StepType:   stepType,
+		}
+	}
+	req := &types.RollbackRequest{Namespace: "game", GroupName: "data"}
+	tests := []struct {
+		name     string
+		req      *types.RollbackRequest
+		record   *types.Record
+		wantEnvs map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "Test_newRollbackStep_1",
+			req:  req,
+			record: newRecord(42, types.RecordVersion, &types.Step{
+				Name:        "Ftp-Operator",
+				Phase:       types.StepSucceeded,
+				Envs:        map[string]string{types.VersionFlag: "1", types.PublisherFtpMkdir: "20201030_1"},
+				SharingData: map[string]string{types.PublisherReleaseDir: "20201030_1"},
+				Remarks:     []string{"uploaded 1 files"},
+			}),
+			wantEnvs: map[string]string{
+				types.VersionFlag:                 "1",
+				types.PublisherFtpMkdir:           "20201030_1",
+				types.PublisherRollbackRecordId:   "42",
+				types.PublisherRollbackReleaseDir: "20201030_1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test_newRollbackStep_2",
+			req:  req,
+			record: newRecord(43, types.RecordVersion, &types.Step{
+				Name:  "Ftp-Operator",
+				Phase: types.StepSucceeded,
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "Test_newRollbackStep_3",
+			req:     req,
+			record:  newRecord(44, types.RecordVersion, &types.Step{Name: "Ftp-Operator", Phase: types.StepFailed}),
+			wantErr: true,
+		},
+		{
+			name:    "Test_newRollbackStep_4",
+			req:     req,
+			record:  newRecord(45, types.RecordDefault, &types.Step{Name: "Ftp-Operator", Phase: types.StepSucceeded}),
+			wantErr: true,
+		},
+		{
+			name:    "Test_newRollbackStep_5",
+			req:     &types.RollbackRequest{Namespace: "game", GroupName: "client"},
+			record:  newRecord(46, types.RecordVersion, &types.Step{Name: "Ftp-Operator", Phase: types.StepSucceeded}),
+			wantErr: true,
+		},
+		{
+			name: "Test_newRollbackStep_6",
+			req:  req,
+			record: newRecord(47, types.RecordVersion, &types.Step{
+				Name:        "Git-Operator",
+				Phase:       types.StepSucceeded,
+				Envs:        map[string]string{types.VersionFlag: "1"},
+				SharingData: map[string]string{types.PublisherGitSha: "9e8e0b390897"},
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newRollbackStep(tt.req, tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRollbackStep() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Envs, tt.wantEnvs) {
+				t.Errorf("newRollbackStep() envs = %v, want %v", got.Envs, tt.wantEnvs)
+			}
+			if got.Phase != types.StepPending || len(got.Remarks) != 0 {
+				t.Errorf("newRollbackStep() phase = %v remarks = %v", got.Phase, got.Remarks)
+			}
+		})
+	}
+}
+
+func Test_isRollbackStep(t *testing.T) {
+	tests := []struct {
+		name string
+		step *types.Step
+		want bool
+	}{
+		{name: "Test_isRollbackStep_1", step: &types.Step{SharingData: map[string]string{types.PublisherRollbackRecordId: "42"}}, want: true},
+		{name: "Test_isRollbackStep_2", step: &types.Step{SharingData: map[string]string{types.PublisherReleaseDir: "20201030_1"}}, want: false},
+		{name: "Test_isRollbackStep_3", step: &types.Step{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRollbackStep(tt.step); got != tt.want {
+				t.Errorf("isRollbackStep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,18 +6,25 @@ import (
 	"fmt"
 	"github.com/Shanghai-Lunara/publisher/pkg/conf"
 	"github.com/Shanghai-Lunara/publisher/pkg/dao"
+	"github.com/Shanghai-Lunara/publisher/pkg/notifier"
 	"github.com/Shanghai-Lunara/publisher/pkg/types"
 	"k8s.io/klog/v2"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 func NewScheduler(broadcast chan *broadcast, d *dao.Dao, c *conf.Config) *Scheduler {
+	n, err := notifier.New(&c.Notifications)
+	if err != nil {
+		klog.Fatal(err)
+	}
 	s := &Scheduler{
 		items:     make(map[types.Namespace]*Groups, 0),
 		broadcast: broadcast,
 		dao:       d,
+		notifier:  n,
 	}
 	for _, v := range c.Projects {
 		s.items[types.Namespace(v.Namespace)] = &Groups{
@@ -38,6 +45,11 @@ type Scheduler struct {
 	dao       *dao.Dao
 	items     map[types.Namespace]*Groups
 	broadcast chan<- *broadcast
+	notifier  *notifier.Dispatcher
+	// closing would be 1 after the Server started shutting down, and no more steps would be run
+	closing int32
+	// records was the WaitGroup of the pending recordStep goroutines
+	records sync.WaitGroup
 }
 
 type Groups struct {
@@ -52,17 +64,21 @@ type Group struct {
 func (s *Scheduler) removeRunner(id int32) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	for _, v := range s.items {
-		for _, v2 := range v.items {
+	for k, v := range s.items {
+		for k2, v2 := range v.items {
 			if name, ok := v2.Ids[id]; ok {
 				delete(v2.Runners, name)
 				delete(v2.Ids, id)
+				// the disconnections during shutting down were expected
+				if atomic.LoadInt32(&s.closing) == 0 {
+					s.notifier.Dispatch(notifier.NewRunnerDisconnectedEvent(k, k2, name))
+				}
 			}
 		}
 	}
 }
 
-func (s *Scheduler) handle(message []byte, clientId int32) (res []byte, err error) {
+func (s *Scheduler) handle(message []byte, clientId int32, id *identity) (res []byte, err error) {
 	req := &types.Request{}
 	if err = req.Unmarshal(message); err != nil {
 		klog.V(2).Info(err)
@@ -84,10 +100,17 @@ func (s *Scheduler) handle(message []byte, clientId int32) (res []byte, err erro
 		// RunStep must be sent from the Dashboard in the Scheduler handler.
 		// And then the command would be transmitted to the specific Runner.
 		// At the same time, the Runner status would be changed and synced to all dashboards.
-		res, err = s.handleRunStep(req.Data)
+		a := s.newAudit(req.Type.ServiceAPI, req.Data, id)
+		res, err = s.handleRunStep(req.Data, id)
+		s.goRecordAudit(a, err)
 	case types.UpdateStep:
+		var a *types.Audit
+		if req.Type.Body == types.BodyDashboard {
+			a = s.newAudit(req.Type.ServiceAPI, req.Data, id)
+		}
 		var tn *triggerNext
 		res, tn, err = s.handleUpdateStep(req.Data, req.Type.Body)
+		s.goRecordAudit(a, err)
 		if req.Type.Body == types.BodyRunner && tn != nil && tn.next == true {
 			go func() {
 				_, err := s.triggerRunStep(tn.ri, tn.step)
@@ -109,6 +132,16 @@ func (s *Scheduler) handle(message []byte, clientId int32) (res []byte, err erro
 	case types.ServiceAPIListVersionsRequest:
 		reqType.ServiceAPI = types.ServiceAPIListVersionsResponse
 		res, err = s.handleListRecordsRequest(req.Data)
+	case types.ServiceAPIListAuditsRequest:
+		reqType.ServiceAPI = types.ServiceAPIListAuditsResponse
+		res, err = s.handleListAuditsRequest(req.Data)
+	case types.ServiceAPIListRollbackVersionsRequest:
+		reqType.ServiceAPI = types.ServiceAPIListRollbackVersionsResponse
+		res, err = s.handleListRollbackVersionsRequest(req.Data)
+	case types.ServiceAPIRollbackRequest:
+		// RollbackRequest must be sent from the Dashboard, and it would be audited as a RunStep
+		reqType.ServiceAPI = types.ServiceAPIRollbackResponse
+		res, err = s.handleRollbackRequest(req.Data, id)
 	}
 	if err != nil {
 		klog.V(2).Info(err)
@@ -231,6 +264,7 @@ const (
 	ErrGroupWasNotExisted     = "error: namespace:%s groupName:%s was not existed"
 	ErrRunnerWasNotExisted    = "error: namespace:%s groupName:%s runner:%s was not existed"
 	ErrStepWasNotExisted      = "error: namespace:%s groupName:%s runner:%s step:%s was not existed"
+	ErrSchedulerWasClosing    = "error: the Scheduler was shutting down, step:%s was refused"
 )
 
 func (s *Scheduler) getGroup(namespace types.Namespace, groupName types.GroupName) (*Group, error) {
@@ -245,12 +279,15 @@ func (s *Scheduler) getGroup(namespace types.Namespace, groupName types.GroupNam
 	}
 }
 
-func (s *Scheduler) handleRunStep(data []byte) (res []byte, err error) {
+func (s *Scheduler) handleRunStep(data []byte, id *identity) (res []byte, err error) {
 	req := &types.RunStepRequest{}
 	if err = req.Unmarshal(data); err != nil {
 		klog.V(2).Info(err)
 		return nil, err
 	}
+	if atomic.LoadInt32(&s.closing) == 1 {
+		return nil, fmt.Errorf(ErrSchedulerWasClosing, req.Step.Name)
+	}
 	var g *Group
 	if g, err = s.getGroup(req.Namespace, req.GroupName); err != nil {
 		klog.V(2).Info(err)
@@ -274,12 +311,15 @@ func (s *Scheduler) handleRunStep(data []byte) (res []byte, err error) {
 			exist = true
 			v = *req.Step.DeepCopy()
 			v.Phase = types.StepRunning
+			// the trigger user was only sent to the Runner rather than kept in the Step of the Scheduler
+			delete(v.Envs, types.PublisherTriggerUser)
 			// collecting sharing data
 			if v.SharingSetting == true {
 				klog.Info("trigger collectSharingData name:", v.Name)
 				s.collectSharingData(g, req.RunnerName, &v)
 			}
 			waitStep = v.DeepCopy()
+			withTriggerUser(waitStep, id)
 			// sync for updating
 			if err = s.updateStepToDashboard(req.Namespace, req.GroupName, req.RunnerName, &v); err != nil {
 				klog.V(2).Info(err)
@@ -324,6 +364,10 @@ func (s *Scheduler) collectSharingData(g *Group, filterRunnerName string, step *
 		}
 		for _, v2 := range v.Steps {
 			for k, v3 := range v2.SharingData {
+				// the rollback mark only belonged to the rolled back Step, see isRollbackStep
+				if k == types.PublisherRollbackRecordId {
+					continue
+				}
 				step.SharingData[k] = v3
 			}
 		}
@@ -370,10 +414,16 @@ func (s *Scheduler) handleUpdateStep(data []byte, body types.Body) (res []byte,
 		case false:
 			if v.Name == req.Step.Name {
 				exist = true
+				lastPhase := v.Phase
 				v = req.Step
 				// save to db
 				if body == types.BodyRunner {
-					go s.recordStep(ri, v.DeepCopy())
+					s.goRecordStep(ri, v.DeepCopy())
+					// observe and notify only when the phase was changed, the re-sent finished step would not be counted again
+					if lastPhase != v.Phase {
+						observeStep(req.Namespace, req.GroupName, &v)
+						s.notifier.Dispatch(notifier.NewStepEvent(req.Namespace, req.GroupName, req.RunnerName, &v))
+					}
 				}
 				// sync for updating
 				if err = s.updateStepToDashboard(req.Namespace, req.GroupName, req.RunnerName, &v); err != nil {
@@ -381,8 +431,9 @@ func (s *Scheduler) handleUpdateStep(data []byte, body types.Body) (res []byte,
 					return nil, tn, err
 				}
 				// if the request body was types.BodyRunner and the step.Phase was the types.StepSucceeded,
-				// it means that the Scheduler should trigger automatic running
-				if body == types.BodyRunner && v.Phase == types.StepSucceeded {
+				// it means that the Scheduler should trigger automatic running, except for the rollback
+				// which only re-pointed the release and should not run the downstream steps again
+				if body == types.BodyRunner && v.Phase == types.StepSucceeded && !isRollbackStep(&v) {
 					next = true
 				}
 			}
@@ -557,19 +608,50 @@ func (s *Scheduler) triggerRunStep(ri *types.RunnerInfo, step *types.Step) (res
 		klog.V(2).Info(err)
 		return nil, err
 	}
-	return s.handleRunStep(data)
+	return s.handleRunStep(data, nil)
+}
+
+// goRecordStep runs recordStep in a new goroutine which would be waited for by waitForRecords
+func (s *Scheduler) goRecordStep(ri *types.RunnerInfo, step *types.Step) {
+	s.records.Add(1)
+	go func() {
+		defer s.records.Done()
+		s.recordStep(ri, step)
+	}()
+}
+
+// waitForRecords blocks until all the pending records have been written or the ctx was done
+func (s *Scheduler) waitForRecords(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.records.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) setClosing() {
+	atomic.StoreInt32(&s.closing, 1)
 }
 
 func (s *Scheduler) recordStep(ri *types.RunnerInfo, step *types.Step) {
 	data, err := step.Marshal()
 	if err != nil {
 		klog.V(2).Info(err)
+		recordStepErrorsTotal.Inc()
 		return
 	}
 	db := s.dao.Mysql.Master()
 	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
 		klog.V(2).Info(err)
+		recordStepErrorsTotal.Inc()
+		return
 	}
 	_, err = tx.Query("INSERT INTO records (`namespace`,`groupName`,`runnerName`,`stepInfo`,`stepType`,`createdTM`) values (?,?,?,?,?,?)",
 		ri.Namespace,
@@ -580,6 +662,7 @@ func (s *Scheduler) recordStep(ri *types.RunnerInfo, step *types.Step) {
 		time.Now().Unix())
 	if err != nil {
 		klog.V(2).Info(err)
+		recordStepErrorsTotal.Inc()
 		if err := tx.Rollback(); err != nil {
 			klog.V(2).Info(err)
 		}
@@ -587,6 +670,7 @@ func (s *Scheduler) recordStep(ri *types.RunnerInfo, step *types.Step) {
 	}
 	if err = tx.Commit(); err != nil {
 		klog.V(2).Info(err)
+		recordStepErrorsTotal.Inc()
 		return
 	}
 }
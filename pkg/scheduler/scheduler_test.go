@@ -1 +1,109 @@
-package scheduler
\ No newline at end of file
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/conf"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/gorilla/websocket"
+)
+
+func Test_Scheduler_waitForRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		pending time.Duration
+		timeout time.Duration
+		wantErr error
+	}{
+		{name: "Test_Scheduler_waitForRecords_1", timeout: time.Second},
+		{name: "Test_Scheduler_waitForRecords_2", pending: time.Millisecond * 50, timeout: time.Second},
+		{name: "Test_Scheduler_waitForRecords_3", pending: time.Second, timeout: time.Millisecond * 50, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scheduler{}
+			if tt.pending > 0 {
+				s.records.Add(1)
+				go func() {
+					defer s.records.Done()
+					time.Sleep(tt.pending)
+				}()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			if err := s.waitForRecords(ctx); err != tt.wantErr {
+				t.Errorf("waitForRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Server_Close(t *testing.T) {
+	c := &conf.Config{PublisherService: conf.PublisherService{ShutdownTimeoutInSec: 5}}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		conf:        c,
+		connections: NewConnections(ctx, c),
+		httpServer:  &http.Server{},
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(s.connections.handlerRunner))
+	defer ts.Close()
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	for {
+		s.connections.mu.RLock()
+		n := len(s.connections.items)
+		s.connections.mu.RUnlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// a pending record which would be waited for
+	var recorded int32
+	s.connections.scheduler.records.Add(1)
+	go func() {
+		defer s.connections.scheduler.records.Done()
+		time.Sleep(time.Millisecond * 100)
+		atomic.StoreInt32(&recorded, 1)
+	}()
+
+	s.Close()
+
+	if atomic.LoadInt32(&recorded) != 1 {
+		t.Error("Close() returned before the pending record was written")
+	}
+	_, message, err := client.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &types.Request{}
+	if err = req.Unmarshal(message); err != nil {
+		t.Fatal(err)
+	}
+	if req.Type.ServiceAPI != types.Shutdown {
+		t.Errorf("Close() message = %v, want %v", req.Type.ServiceAPI, types.Shutdown)
+	}
+	if _, _, err = client.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("Close() close error = %v, want %d", err, websocket.CloseGoingAway)
+	}
+	data, err := (&types.RunStepRequest{Step: types.Step{Name: "Robot"}}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.connections.scheduler.handleRunStep(data, nil); err == nil || err.Error() != fmt.Sprintf(ErrSchedulerWasClosing, "Robot") {
+		t.Errorf("handleRunStep() error = %v, want %s", err, fmt.Sprintf(ErrSchedulerWasClosing, "Robot"))
+	}
+}
@@ -4,35 +4,74 @@ import (
 	"context"
 	"fmt"
 	"github.com/Shanghai-Lunara/publisher/pkg/conf"
+	"github.com/Shanghai-Lunara/publisher/pkg/health"
 	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/klog/v2"
 	"net"
 	"net/http"
+	"time"
 )
 
 type Server struct {
+	conf        *conf.Config
 	connections *connections
+	httpServer  *http.Server
+	ctx         context.Context
+	cancel      context.CancelFunc
 }
 
 func (s *Server) initWSServer(addr string) {
 	klog.Info("initWSService")
 	http.HandleFunc(types.WebsocketHandlerRunner, s.connections.handlerRunner)
 	http.HandleFunc(types.WebsocketHandlerDashboard, s.connections.handlerDashboard)
+	http.Handle(types.HttpHandlerMetrics, promhttp.Handler())
+	http.Handle(types.HttpHandlerHealthz, health.LivenessHandler())
+	http.Handle(types.HttpHandlerReadyz, health.ReadinessHandler(s.readinessChecks(), nil))
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
 		klog.Fatal(err)
 	}
-	klog.Fatal(http.Serve(l, nil))
+	if err = s.httpServer.Serve(l); err != nil && err != http.ErrServerClosed {
+		klog.Fatal(err)
+	}
 }
 
+// Close shuts the Server down gracefully. It stops accepting new connections, notifies all the dashboards
+// and Runners, and then waits for the pending records being written into the db until the deadline.
 func (s *Server) Close() {
-
+	timeout := s.conf.PublisherService.ShutdownTimeoutInSec
+	if timeout <= 0 {
+		timeout = conf.DefaultShutdownTimeoutInSec
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
+	defer cancel()
+	klog.Info("Server was shutting down")
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		klog.V(2).Info(err)
+	}
+	s.connections.shutdown(ctx)
+	if err := s.connections.scheduler.waitForRecords(ctx); err != nil {
+		klog.Warningf("Server waiting for the pending records err:%v", err)
+	}
+	if err := s.connections.scheduler.notifier.Wait(ctx); err != nil {
+		klog.Warningf("Server waiting for the pending notifications err:%v", err)
+	}
+	s.cancel()
+	klog.Info("Server was closed")
 }
 
 func NewServer(c *conf.Config) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
 	s := &Server{
-		connections: NewConnections(context.Background(), c),
+		conf:        c,
+		connections: NewConnections(ctx, c),
+		httpServer:  &http.Server{},
+		ctx:         ctx,
+		cancel:      cancel,
 	}
+	prometheus.MustRegister(newMetricsCollector(s.connections))
 	go s.initWSServer(fmt.Sprintf(":%d", c.PublisherService.ListenPort))
 	return s
 }
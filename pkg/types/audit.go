@@ -0,0 +1,45 @@
+package types
+
+// +Protocol
+// Audit was the trail of an action which was triggered by a web dashboard user, such as RunStep and UpdateStep.
+type Audit struct {
+	Id int32 `json:"id" protobuf:"varint,1,opt,name=id"`
+	// Actor was the user of the web dashboard
+	Actor string `json:"actor" protobuf:"bytes,2,opt,name=actor"`
+	// SourceIP was the remote address of the web dashboard
+	SourceIP   string     `json:"sourceIp" protobuf:"bytes,3,opt,name=sourceIp"`
+	ServiceAPI ServiceAPI `json:"serviceApi" protobuf:"bytes,4,opt,name=serviceApi"`
+	Namespace  Namespace  `json:"namespace" protobuf:"bytes,5,opt,name=namespace"`
+	GroupName  GroupName  `json:"groupName" protobuf:"bytes,6,opt,name=groupName"`
+	RunnerName string     `json:"runnerName" protobuf:"bytes,7,opt,name=runnerName"`
+	StepName   string     `json:"stepName" protobuf:"bytes,8,opt,name=stepName"`
+	// Changes were the differences of the Envs, Policy and Available between the step before and after the action
+	Changes   []AuditChange `json:"changes" protobuf:"bytes,9,rep,name=changes"`
+	CreatedTM int32         `json:"createdTM" protobuf:"varint,10,opt,name=createdTM"`
+}
+
+// AuditChange was a changed field of a Step, the Field would be such as `policy`, `available` or `envs.KEY`.
+type AuditChange struct {
+	Field  string `json:"field" protobuf:"bytes,1,opt,name=field"`
+	Before string `json:"before" protobuf:"bytes,2,opt,name=before"`
+	After  string `json:"after" protobuf:"bytes,3,opt,name=after"`
+}
+
+// ListAuditsRequest
+type ListAuditsRequest struct {
+	Namespace  Namespace `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	GroupName  GroupName `json:"groupName" protobuf:"bytes,2,opt,name=groupName"`
+	RunnerName string    `json:"runnerName" protobuf:"bytes,3,opt,name=runnerName"`
+	// Actor specifies the filter condition in sql where, it would be ignored if it was empty
+	Actor string `json:"actor" protobuf:"bytes,4,opt,name=actor"`
+	// page specifies the offset of the first row to return
+	Page   int32 `json:"page" protobuf:"varint,5,opt,name=page"`
+	Length int32 `json:"length" protobuf:"varint,6,opt,name=length"`
+}
+
+// ListAuditsResponse
+type ListAuditsResponse struct {
+	Params      ListAuditsRequest `json:"params" protobuf:"bytes,1,opt,name=params"`
+	Audits      []Audit           `json:"audits" protobuf:"bytes,2,rep,name=audits"`
+	AuditNumber int32             `json:"auditNumber" protobuf:"varint,3,opt,name=auditNumber"`
+}
@@ -9,10 +9,70 @@ const (
 	// ws
 	WebsocketHandlerRunner    = "/runner"
 	WebsocketHandlerDashboard = "/dashboard"
+	// http
+	HttpHandlerMetrics = "/metrics"
+	HttpHandlerHealthz = "/healthz"
+	HttpHandlerReadyz  = "/readyz"
 
 	PublisherProjectDir = "PUBLISHER_PROJECT_DIR"
 	// git config
 	PublisherGitBranch = "PUBLISHER_GIT_BRANCH"
+	// PublisherGitDiscardChanges discards the local changes before checking out when it was `true` by default,
+	// otherwise the dirty work tree would fail the Step
+	PublisherGitDiscardChanges = "PUBLISHER_GIT_DISCARD_CHANGES"
+	// PublisherGitUrl was the remote which would be cloned into the PUBLISHER_PROJECT_DIR when the dir was missing or empty
+	PublisherGitUrl = "PUBLISHER_GIT_URL"
+	// PublisherGitTag checks out the tag instead of the branch
+	PublisherGitTag = "PUBLISHER_GIT_TAG"
+	// PublisherGitCommit checks out the exact commit, it takes precedence over the tag and the branch
+	PublisherGitCommit = "PUBLISHER_GIT_COMMIT"
+	// PublisherGitDepth was the depth of the shallow clone and fetch, the full history would be fetched if it was empty
+	PublisherGitDepth = "PUBLISHER_GIT_DEPTH"
+	// PublisherGitSubmodules updates the submodules recursively after checking out when it was `true`
+	PublisherGitSubmodules = "PUBLISHER_GIT_SUBMODULES"
+	// PublisherGitLfs pulls the LFS objects after checking out when it was `true`
+	PublisherGitLfs = "PUBLISHER_GIT_LFS"
+	// PublisherGitSha was the full sha of the checked out commit
+	PublisherGitSha = "PUBLISHER_GIT_SHA"
+	// PublisherGitShortSha was the short sha of the checked out commit
+	PublisherGitShortSha = "PUBLISHER_GIT_SHORT_SHA"
+	// PublisherGitAuthor was the author of the checked out commit
+	PublisherGitAuthor = "PUBLISHER_GIT_AUTHOR"
+	// PublisherGitSubject was the subject of the checked out commit
+	PublisherGitSubject = "PUBLISHER_GIT_SUBJECT"
+	// PublisherGitCommand was the mode of the git operator, such as `pulling and waiting` or `adding and committing`
+	PublisherGitCommand = "PUBLISHER_GIT_COMMAND"
+	// PublisherGitCommitMessage was the text/template of the commit message in the committing mode
+	PublisherGitCommitMessage = "PUBLISHER_GIT_COMMIT_MESSAGE"
+	// PublisherGitPushTag was the text/template of the tag which would be created and pushed with the commit
+	PublisherGitPushTag = "PUBLISHER_GIT_PUSH_TAG"
+	// PublisherGitPushedTag was the tag which had been created and pushed, it would be set into the SharingData
+	PublisherGitPushedTag = "PUBLISHER_GIT_PUSHED_TAG"
+	// PublisherGitUserName and PublisherGitUserEmail were the committer, the git config would be used if they were empty
+	PublisherGitUserName  = "PUBLISHER_GIT_USER_NAME"
+	PublisherGitUserEmail = "PUBLISHER_GIT_USER_EMAIL"
+	// PublisherTriggerUser was the dashboard user who triggered the Step, it would be set by the Scheduler
+	PublisherTriggerUser = "PUBLISHER_TRIGGER_USER"
+	// PublisherVersion was the version of the build which was being published
+	PublisherVersion = "PUBLISHER_VERSION"
+
+	// release config, they were shared by the ftp and sftp operators
+	// PublisherReleaseDirTemplate was the text/template of the release directory name, `{{.Date}}_{{.Seq}}` by default
+	PublisherReleaseDirTemplate = "PUBLISHER_RELEASE_DIR_TEMPLATE"
+	// PublisherReleaseDir was the created release directory, it would be published into the SharingData
+	PublisherReleaseDir = "PUBLISHER_RELEASE_DIR"
+	// PublisherReleaseLatestFile was the pointer file in the work dir, its content would be the latest release directory
+	PublisherReleaseLatestFile = "PUBLISHER_RELEASE_LATEST_FILE"
+	// PublisherReleaseKeep was the number of the latest releases which would be kept by the retention
+	PublisherReleaseKeep = "PUBLISHER_RELEASE_KEEP"
+	// PublisherReleaseKeepDays keeps the releases which were newer than the days
+	PublisherReleaseKeepDays = "PUBLISHER_RELEASE_KEEP_DAYS"
+	// PublisherReleasePruneDryRun only lists the releases which would be removed when it was `true`
+	PublisherReleasePruneDryRun = "PUBLISHER_RELEASE_PRUNE_DRY_RUN"
+	// PublisherRollbackRecordId was the id of the version record which was being rolled back to
+	PublisherRollbackRecordId = "PUBLISHER_ROLLBACK_RECORD_ID"
+	// PublisherRollbackReleaseDir was the release directory which was published by the rolled back record
+	PublisherRollbackReleaseDir = "PUBLISHER_ROLLBACK_RELEASE_DIR"
 
 	// ftp config
 	PublisherFtpHost     = "ftp_host"
@@ -22,6 +82,27 @@ const (
 	PublisherFtpWorkDir  = "ftp_work_dir"
 	PublisherFtpTimeout  = "ftp_timeout"
 	PublisherFtpMkdir    = "PUBLISHER_FTP_MKDIR"
+	// PublisherFtpWorkers was the number of the parallel uploading connections
+	PublisherFtpWorkers = "ftp_workers"
+	// PublisherFtpVerify was the verification after each upload, it could be `size`, `checksum` or `none`
+	PublisherFtpVerify = "ftp_verify"
+	// PublisherFtpSync was the sync mode, only the changed files would be uploaded when it was `incremental`.
+	// The incremental sync could not be combined with the PUBLISHER_FTP_MKDIR, because each release directory was new.
+	PublisherFtpSync = "ftp_sync"
+	// PublisherFtpSyncDelete deletes the remote files which were no longer present when it was `true`
+	PublisherFtpSyncDelete = "ftp_sync_delete"
+
+	// sftp config
+	PublisherSftpHost     = "sftp_host"
+	PublisherSftpPort     = "sftp_port"
+	PublisherSftpUsername = "sftp_username"
+	PublisherSftpPassword = "sftp_password"
+	// PublisherSftpPrivateKey was the path of the private key file on the Runner
+	PublisherSftpPrivateKey = "sftp_private_key"
+	// PublisherSftpHostKey was the pinned host key, it could be an authorized_keys line or a SHA256 fingerprint
+	PublisherSftpHostKey = "sftp_host_key"
+	PublisherSftpWorkDir = "sftp_work_dir"
+	PublisherSftpTimeout = "sftp_timeout"
 
 	// svn config
 	PublisherSvnHost          = "svn_host"
@@ -32,12 +113,156 @@ const (
 	PublisherSvnWorkDir       = "svn_work_dir"
 	PublisherSvnCommitMessage = "svn_commit_message"
 	PublisherSvnCommand       = "svn_command"
+	// PublisherSvnUrl was the url of the repository, it overrides the svn://username@host:port/remote_dir one,
+	// such as file:///data/svn/project/trunk
+	PublisherSvnUrl = "svn_url"
+	// PublisherSvnMode was the way of the pulling, it could be `checkout` by default or `update`
+	PublisherSvnMode = "svn_mode"
+	// PublisherSvnRevision pins the revision which would be checked out, it could be a number or `HEAD` by default
+	PublisherSvnRevision = "svn_revision"
+	// PublisherSvnSwitchUrl switches the working copy to the branch or the tag, such as ^/tags/1.0.0
+	PublisherSvnSwitchUrl = "svn_switch_url"
+	// PublisherSvnResolvedRevision and PublisherSvnResolvedUrl were the revision and the url of the working copy
+	// after the pulling, they would be put into the SharingData
+	PublisherSvnResolvedRevision = "PUBLISHER_SVN_REVISION"
+	PublisherSvnResolvedUrl      = "PUBLISHER_SVN_URL"
+	// PublisherSvnCommitMaxFiles and PublisherSvnCommitMaxBytes guard the committing mode, the commit would fail
+	// when the changed files or the total size of the added and modified files exceeded them. Zero means no limit.
+	PublisherSvnCommitMaxFiles = "svn_commit_max_files"
+	PublisherSvnCommitMaxBytes = "svn_commit_max_bytes"
+
+	// script config
+	// PublisherScriptBody was the content of the script, it takes precedence over the PublisherScriptPath
+	PublisherScriptBody = "script_body"
+	// PublisherScriptPath was the path of the script file on the Runner
+	PublisherScriptPath = "script_path"
+	// PublisherScriptWorkDir was the working dir of the script
+	PublisherScriptWorkDir = "script_work_dir"
+	// PublisherScriptShell was the interpreter of the script, it was `sh` by default for the body,
+	// and the script file would be executed directly if it was empty
+	PublisherScriptShell = "script_shell"
+	// PublisherScriptEnvs was the comma separated keys of the Envs which would be exported to the script,
+	// the SharingData would always be exported
+	PublisherScriptEnvs = "script_envs"
+
+	// archive config
+	// PublisherArchiveSourceDir was the directory whose files would be archived with their relative paths
+	PublisherArchiveSourceDir = "archive_source_dir"
+	// PublisherArchiveOutput was the path of the archive, it was relative to the archive_source_dir if it was not absolute
+	PublisherArchiveOutput = "archive_output"
+	// PublisherArchiveFormat could be `zip` or `tar.gz`, it would be detected by the suffix of the archive_output if it was empty
+	PublisherArchiveFormat = "archive_format"
+	// PublisherArchiveInclude and PublisherArchiveExclude were the comma or newline separated patterns of the relative paths,
+	// such as `bin/**, *.json`. All the files would be included if the archive_include was empty.
+	PublisherArchiveInclude = "archive_include"
+	PublisherArchiveExclude = "archive_exclude"
+	// PublisherArchiveTargetPath was the remote directory of the archive and its manifest in the PUBLISHER_UPLOAD_FILES
+	PublisherArchiveTargetPath = "archive_target_path"
+	// PublisherArchiveFile and PublisherArchiveSha256 were the created archive and its checksum in the SharingData
+	PublisherArchiveFile   = "PUBLISHER_ARCHIVE_FILE"
+	PublisherArchiveSha256 = "PUBLISHER_ARCHIVE_SHA256"
+	// PublisherUploadFiles was the json array of the types.UploadFile in the SharingData, the ftp and sftp operators
+	// would upload them along with their own UploadFiles
+	PublisherUploadFiles = "PUBLISHER_UPLOAD_FILES"
+
+	// http config
+	// PublisherHttpMethod was the method of the request, it was GET by default
+	PublisherHttpMethod = "http_method"
+	// PublisherHttpUrl was the text/template of the url
+	PublisherHttpUrl = "http_url"
+	// PublisherHttpHeaders were the newline separated `Key: Value` headers, the values were text/templates
+	PublisherHttpHeaders = "http_headers"
+	// PublisherHttpBody was the text/template of the body, such as {"version":{{json .SharingData.PUBLISHER_VERSION}}}
+	PublisherHttpBody = "http_body"
+	// PublisherHttpExpectedStatus was the comma separated status codes or ranges, it was `200-299` by default
+	PublisherHttpExpectedStatus = "http_expected_status"
+	// PublisherHttpRetries was the number of the retries after the network errors, the 5xx and the 429 responses,
+	// only the GET, HEAD, OPTIONS and TRACE requests were retried unless the PublisherHttpRetryNonIdempotent was `true`
+	PublisherHttpRetries = "http_retries"
+	// PublisherHttpRetryNonIdempotent retries the other methods such as POST, the hook may be run more than once
+	PublisherHttpRetryNonIdempotent = "http_retry_non_idempotent"
+	// PublisherHttpRetryIntervalInMs was the interval between the retries, 1000 by default
+	PublisherHttpRetryIntervalInMs = "http_retry_interval_ms"
+	// PublisherHttpTimeout was the timeout in seconds of each attempt, 30 by default
+	PublisherHttpTimeout = "http_timeout"
+	// PublisherHttpExtract was the comma or newline separated `KEY=path` pairs, the fields of the json response
+	// at the dot separated paths would be put into the SharingData with the keys, such as PUBLISHER_TASK_ID=data.id
+	PublisherHttpExtract = "http_extract"
+	// PublisherHttpStatus was the status code of the last response in the SharingData
+	PublisherHttpStatus = "PUBLISHER_HTTP_STATUS"
+
+	// docker config
+	// PublisherDockerHost was the address of the Docker Engine API, it was unix:///var/run/docker.sock by default
+	PublisherDockerHost = "docker_host"
+	// PublisherDockerContextDir was the build context, the files matched by its .dockerignore were not sent
+	PublisherDockerContextDir = "docker_context_dir"
+	// PublisherDockerfile was the path of the Dockerfile relative to the context dir, it was `Dockerfile` by default
+	PublisherDockerfile = "docker_dockerfile"
+	// PublisherDockerImage was the repository of the image, such as registry.example.com/game/server
+	PublisherDockerImage = "docker_image"
+	// PublisherDockerTags were the comma separated text/templates of the tags, `{{.Version}},{{.GitShortSha}}` by default.
+	// The empty tags were skipped, and the image would be tagged as `latest` if all of them were empty.
+	PublisherDockerTags = "docker_tags"
+	// PublisherDockerBuildArgs were the newline separated `KEY=VALUE` build args, the values were text/templates
+	PublisherDockerBuildArgs = "docker_build_args"
+	// PublisherDockerNoCache builds the image without the cache when it was `true`
+	PublisherDockerNoCache = "docker_no_cache"
+	// PublisherDockerPush pushes the tags after building, it was `true` by default
+	PublisherDockerPush = "docker_push"
+	// PublisherDockerRegistryServer, PublisherDockerRegistryUsername and PublisherDockerRegistryPassword
+	// were the credentials of the registry for pushing
+	PublisherDockerRegistryServer   = "docker_registry_server"
+	PublisherDockerRegistryUsername = "docker_registry_username"
+	PublisherDockerRegistryPassword = "docker_registry_password"
+	// PublisherDockerImageName, PublisherDockerImageId and PublisherDockerImageDigest were the first tagged image,
+	// the built image id and the pushed digest in the SharingData
+	PublisherDockerImageName   = "PUBLISHER_DOCKER_IMAGE"
+	PublisherDockerImageId     = "PUBLISHER_DOCKER_IMAGE_ID"
+	PublisherDockerImageDigest = "PUBLISHER_DOCKER_IMAGE_DIGEST"
+
+	// kube config
+	// PublisherKubeApiServer was the address of the Kubernetes API server, such as https://10.0.0.1:6443,
+	// and it was authenticated by the token and the ca file. It takes precedence over the PublisherKubeConfig.
+	PublisherKubeApiServer = "kube_api_server"
+	PublisherKubeToken     = "kube_token"
+	PublisherKubeCaFile    = "kube_ca_file"
+	// PublisherKubeInsecure skips the verification of the API server certificate when it was `true`
+	PublisherKubeInsecure = "kube_insecure"
+	// PublisherKubeConfig was the path of the kubeconfig file whose current context would be used,
+	// the in-cluster service account would be used if neither of it and the PublisherKubeApiServer was set
+	PublisherKubeConfig = "kube_config"
+	// PublisherKubeNamespace was the namespace of the workload, it was `default` by default
+	PublisherKubeNamespace = "kube_namespace"
+	// PublisherKubeKind and PublisherKubeName were the workload whose image would be updated,
+	// the kind could be `Deployment` by default or `StatefulSet`
+	PublisherKubeKind = "kube_kind"
+	PublisherKubeName = "kube_name"
+	// PublisherKubeContainer was the container whose image would be updated, it could be empty for the single container
+	PublisherKubeContainer = "kube_container"
+	// PublisherKubeImage was the text/template of the image, it was the PUBLISHER_DOCKER_IMAGE in the SharingData by default
+	PublisherKubeImage = "kube_image"
+	// PublisherKubeManifest was the text/template of the yaml or json manifests separated by `---`, they would be
+	// applied by the server-side apply instead of updating the image, and the Deployments and the StatefulSets
+	// would be waited for
+	PublisherKubeManifest = "kube_manifest"
+	// PublisherKubeTimeout was the timeout in seconds of waiting for the rollout, 300 by default
+	PublisherKubeTimeout = "kube_timeout"
+	// PublisherKubePollIntervalInMs was the interval of polling the rollout status, 2000 by default
+	PublisherKubePollIntervalInMs = "kube_poll_interval_ms"
+	// PublisherKubeImageName was the rolled out image in the SharingData
+	PublisherKubeImageName = "PUBLISHER_KUBE_IMAGE"
 
 	// version flag
 	VersionFlag = "VersionFlag"
 
 	// Robot
 	RobotDurationInMs = "Robot_Duration_In_MS"
+	// RobotIntervalInMs was the interval of the output lines while the robot was running, 1000 by default
+	RobotIntervalInMs = "Robot_Interval_In_MS"
+	// RobotFailureProbability was the probability between 0 and 1 that the robot fails after the duration
+	RobotFailureProbability = "Robot_Failure_Probability"
+	// RobotFail fails the robot after the duration when it was `true`
+	RobotFail = "Robot_Fail"
 )
 
 const (
@@ -49,6 +274,13 @@ const (
 	StepMessageFormat = "[%s] StepName: [%s] Message: [%s] is starting"
 )
 
+const (
+	// SvnRevisionRemarkFormat was the Remark of the revision and the url which were resolved by the svn operator,
+	// the notifier finds it by the SvnRevisionRemarkPrefix
+	SvnRevisionRemarkFormat = SvnRevisionRemarkPrefix + "%s url: %s"
+	SvnRevisionRemarkPrefix = "svn revision: "
+)
+
 func StepMessage(stepName, action string) string {
 	return fmt.Sprintf(StepMessageFormat, time.Now().Format("2006-01-02 15:04:05"), stepName, action)
 }
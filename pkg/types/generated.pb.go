@@ -43,10 +43,66 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+func (m *Audit) Reset()      { *m = Audit{} }
+func (*Audit) ProtoMessage() {}
+func (*Audit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c55f6b914d72f56, []int{0}
+}
+func (m *Audit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Audit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Audit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Audit.Merge(m, src)
+}
+func (m *Audit) XXX_Size() int {
+	return m.Size()
+}
+func (m *Audit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Audit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Audit proto.InternalMessageInfo
+
+func (m *AuditChange) Reset()      { *m = AuditChange{} }
+func (*AuditChange) ProtoMessage() {}
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c55f6b914d72f56, []int{1}
+}
+func (m *AuditChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuditChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *AuditChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditChange.Merge(m, src)
+}
+func (m *AuditChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuditChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditChange proto.InternalMessageInfo
+
 func (m *CompleteStepRequest) Reset()      { *m = CompleteStepRequest{} }
 func (*CompleteStepRequest) ProtoMessage() {}
 func (*CompleteStepRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{0}
+	return fileDescriptor_5c55f6b914d72f56, []int{2}
 }
 func (m *CompleteStepRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -74,7 +130,7 @@ var xxx_messageInfo_CompleteStepRequest proto.InternalMessageInfo
 func (m *CompleteStepResponse) Reset()      { *m = CompleteStepResponse{} }
 func (*CompleteStepResponse) ProtoMessage() {}
 func (*CompleteStepResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{1}
+	return fileDescriptor_5c55f6b914d72f56, []int{3}
 }
 func (m *CompleteStepResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -102,7 +158,7 @@ var xxx_messageInfo_CompleteStepResponse proto.InternalMessageInfo
 func (m *Group) Reset()      { *m = Group{} }
 func (*Group) ProtoMessage() {}
 func (*Group) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{2}
+	return fileDescriptor_5c55f6b914d72f56, []int{4}
 }
 func (m *Group) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -127,10 +183,66 @@ func (m *Group) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Group proto.InternalMessageInfo
 
+func (m *ListAuditsRequest) Reset()      { *m = ListAuditsRequest{} }
+func (*ListAuditsRequest) ProtoMessage() {}
+func (*ListAuditsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c55f6b914d72f56, []int{5}
+}
+func (m *ListAuditsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAuditsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ListAuditsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditsRequest.Merge(m, src)
+}
+func (m *ListAuditsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAuditsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditsRequest proto.InternalMessageInfo
+
+func (m *ListAuditsResponse) Reset()      { *m = ListAuditsResponse{} }
+func (*ListAuditsResponse) ProtoMessage() {}
+func (*ListAuditsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c55f6b914d72f56, []int{6}
+}
+func (m *ListAuditsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAuditsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ListAuditsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditsResponse.Merge(m, src)
+}
+func (m *ListAuditsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAuditsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditsResponse proto.InternalMessageInfo
+
 func (m *ListGroupNameRequest) Reset()      { *m = ListGroupNameRequest{} }
 func (*ListGroupNameRequest) ProtoMessage() {}
 func (*ListGroupNameRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{3}
+	return fileDescriptor_5c55f6b914d72f56, []int{7}
 }
 func (m *ListGroupNameRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -158,7 +270,7 @@ var xxx_messageInfo_ListGroupNameRequest proto.InternalMessageInfo
 func (m *ListGroupNameResponse) Reset()      { *m = ListGroupNameResponse{} }
 func (*ListGroupNameResponse) ProtoMessage() {}
 func (*ListGroupNameResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{4}
+	return fileDescriptor_5c55f6b914d72f56, []int{8}
 }
 func (m *ListGroupNameResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -186,7 +298,7 @@ var xxx_messageInfo_ListGroupNameResponse proto.InternalMessageInfo
 func (m *ListNamespaceRequest) Reset()      { *m = ListNamespaceRequest{} }
 func (*ListNamespaceRequest) ProtoMessage() {}
 func (*ListNamespaceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{5}
+	return fileDescriptor_5c55f6b914d72f56, []int{9}
 }
 func (m *ListNamespaceRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -214,7 +326,7 @@ var xxx_messageInfo_ListNamespaceRequest proto.InternalMessageInfo
 func (m *ListNamespaceResponse) Reset()      { *m = ListNamespaceResponse{} }
 func (*ListNamespaceResponse) ProtoMessage() {}
 func (*ListNamespaceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{6}
+	return fileDescriptor_5c55f6b914d72f56, []int{10}
 }
 func (m *ListNamespaceResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -242,7 +354,7 @@ var xxx_messageInfo_ListNamespaceResponse proto.InternalMessageInfo
 func (m *ListRecordsRequest) Reset()      { *m = ListRecordsRequest{} }
 func (*ListRecordsRequest) ProtoMessage() {}
 func (*ListRecordsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{7}
+	return fileDescriptor_5c55f6b914d72f56, []int{11}
 }
 func (m *ListRecordsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -270,7 +382,7 @@ var xxx_messageInfo_ListRecordsRequest proto.InternalMessageInfo
 func (m *ListRecordsResponse) Reset()      { *m = ListRecordsResponse{} }
 func (*ListRecordsResponse) ProtoMessage() {}
 func (*ListRecordsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{8}
+	return fileDescriptor_5c55f6b914d72f56, []int{12}
 }
 func (m *ListRecordsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -298,7 +410,7 @@ var xxx_messageInfo_ListRecordsResponse proto.InternalMessageInfo
 func (m *ListRunnerRequest) Reset()      { *m = ListRunnerRequest{} }
 func (*ListRunnerRequest) ProtoMessage() {}
 func (*ListRunnerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{9}
+	return fileDescriptor_5c55f6b914d72f56, []int{13}
 }
 func (m *ListRunnerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -326,7 +438,7 @@ var xxx_messageInfo_ListRunnerRequest proto.InternalMessageInfo
 func (m *ListRunnerResponse) Reset()      { *m = ListRunnerResponse{} }
 func (*ListRunnerResponse) ProtoMessage() {}
 func (*ListRunnerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{10}
+	return fileDescriptor_5c55f6b914d72f56, []int{14}
 }
 func (m *ListRunnerResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -354,7 +466,7 @@ var xxx_messageInfo_ListRunnerResponse proto.InternalMessageInfo
 func (m *LogStreamRequest) Reset()      { *m = LogStreamRequest{} }
 func (*LogStreamRequest) ProtoMessage() {}
 func (*LogStreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{11}
+	return fileDescriptor_5c55f6b914d72f56, []int{15}
 }
 func (m *LogStreamRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -382,7 +494,7 @@ var xxx_messageInfo_LogStreamRequest proto.InternalMessageInfo
 func (m *LogStreamResponse) Reset()      { *m = LogStreamResponse{} }
 func (*LogStreamResponse) ProtoMessage() {}
 func (*LogStreamResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{12}
+	return fileDescriptor_5c55f6b914d72f56, []int{16}
 }
 func (m *LogStreamResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -410,7 +522,7 @@ var xxx_messageInfo_LogStreamResponse proto.InternalMessageInfo
 func (m *PingRequest) Reset()      { *m = PingRequest{} }
 func (*PingRequest) ProtoMessage() {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{13}
+	return fileDescriptor_5c55f6b914d72f56, []int{17}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -438,7 +550,7 @@ var xxx_messageInfo_PingRequest proto.InternalMessageInfo
 func (m *PongResponse) Reset()      { *m = PongResponse{} }
 func (*PongResponse) ProtoMessage() {}
 func (*PongResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{14}
+	return fileDescriptor_5c55f6b914d72f56, []int{18}
 }
 func (m *PongResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -466,7 +578,7 @@ var xxx_messageInfo_PongResponse proto.InternalMessageInfo
 func (m *Record) Reset()      { *m = Record{} }
 func (*Record) ProtoMessage() {}
 func (*Record) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{15}
+	return fileDescriptor_5c55f6b914d72f56, []int{19}
 }
 func (m *Record) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -494,7 +606,7 @@ var xxx_messageInfo_Record proto.InternalMessageInfo
 func (m *RegisterRunnerRequest) Reset()      { *m = RegisterRunnerRequest{} }
 func (*RegisterRunnerRequest) ProtoMessage() {}
 func (*RegisterRunnerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{16}
+	return fileDescriptor_5c55f6b914d72f56, []int{20}
 }
 func (m *RegisterRunnerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -522,7 +634,7 @@ var xxx_messageInfo_RegisterRunnerRequest proto.InternalMessageInfo
 func (m *RegisterRunnerResponse) Reset()      { *m = RegisterRunnerResponse{} }
 func (*RegisterRunnerResponse) ProtoMessage() {}
 func (*RegisterRunnerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{17}
+	return fileDescriptor_5c55f6b914d72f56, []int{21}
 }
 func (m *RegisterRunnerResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -550,7 +662,7 @@ var xxx_messageInfo_RegisterRunnerResponse proto.InternalMessageInfo
 func (m *Request) Reset()      { *m = Request{} }
 func (*Request) ProtoMessage() {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{18}
+	return fileDescriptor_5c55f6b914d72f56, []int{22}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -578,7 +690,7 @@ var xxx_messageInfo_Request proto.InternalMessageInfo
 func (m *Response) Reset()      { *m = Response{} }
 func (*Response) ProtoMessage() {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{19}
+	return fileDescriptor_5c55f6b914d72f56, []int{23}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -606,7 +718,7 @@ var xxx_messageInfo_Response proto.InternalMessageInfo
 func (m *Result) Reset()      { *m = Result{} }
 func (*Result) ProtoMessage() {}
 func (*Result) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{20}
+	return fileDescriptor_5c55f6b914d72f56, []int{24}
 }
 func (m *Result) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -631,10 +743,66 @@ func (m *Result) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Result proto.InternalMessageInfo
 
+func (m *RollbackRequest) Reset()      { *m = RollbackRequest{} }
+func (*RollbackRequest) ProtoMessage() {}
+func (*RollbackRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c55f6b914d72f56, []int{25}
+}
+func (m *RollbackRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *RollbackRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackRequest.Merge(m, src)
+}
+func (m *RollbackRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackRequest proto.InternalMessageInfo
+
+func (m *RollbackResponse) Reset()      { *m = RollbackResponse{} }
+func (*RollbackResponse) ProtoMessage() {}
+func (*RollbackResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c55f6b914d72f56, []int{26}
+}
+func (m *RollbackResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RollbackResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *RollbackResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackResponse.Merge(m, src)
+}
+func (m *RollbackResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RollbackResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackResponse proto.InternalMessageInfo
+
 func (m *RunStepRequest) Reset()      { *m = RunStepRequest{} }
 func (*RunStepRequest) ProtoMessage() {}
 func (*RunStepRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{21}
+	return fileDescriptor_5c55f6b914d72f56, []int{27}
 }
 func (m *RunStepRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -662,7 +830,7 @@ var xxx_messageInfo_RunStepRequest proto.InternalMessageInfo
 func (m *RunStepResponse) Reset()      { *m = RunStepResponse{} }
 func (*RunStepResponse) ProtoMessage() {}
 func (*RunStepResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{22}
+	return fileDescriptor_5c55f6b914d72f56, []int{28}
 }
 func (m *RunStepResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -690,7 +858,7 @@ var xxx_messageInfo_RunStepResponse proto.InternalMessageInfo
 func (m *RunnerInfo) Reset()      { *m = RunnerInfo{} }
 func (*RunnerInfo) ProtoMessage() {}
 func (*RunnerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{23}
+	return fileDescriptor_5c55f6b914d72f56, []int{29}
 }
 func (m *RunnerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -718,7 +886,7 @@ var xxx_messageInfo_RunnerInfo proto.InternalMessageInfo
 func (m *Step) Reset()      { *m = Step{} }
 func (*Step) ProtoMessage() {}
 func (*Step) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{24}
+	return fileDescriptor_5c55f6b914d72f56, []int{30}
 }
 func (m *Step) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -746,7 +914,7 @@ var xxx_messageInfo_Step proto.InternalMessageInfo
 func (m *Type) Reset()      { *m = Type{} }
 func (*Type) ProtoMessage() {}
 func (*Type) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{25}
+	return fileDescriptor_5c55f6b914d72f56, []int{31}
 }
 func (m *Type) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -774,7 +942,7 @@ var xxx_messageInfo_Type proto.InternalMessageInfo
 func (m *UpdateStepRequest) Reset()      { *m = UpdateStepRequest{} }
 func (*UpdateStepRequest) ProtoMessage() {}
 func (*UpdateStepRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{26}
+	return fileDescriptor_5c55f6b914d72f56, []int{32}
 }
 func (m *UpdateStepRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -802,7 +970,7 @@ var xxx_messageInfo_UpdateStepRequest proto.InternalMessageInfo
 func (m *UpdateStepResponse) Reset()      { *m = UpdateStepResponse{} }
 func (*UpdateStepResponse) ProtoMessage() {}
 func (*UpdateStepResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{27}
+	return fileDescriptor_5c55f6b914d72f56, []int{33}
 }
 func (m *UpdateStepResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -830,7 +998,7 @@ var xxx_messageInfo_UpdateStepResponse proto.InternalMessageInfo
 func (m *UploadFile) Reset()      { *m = UploadFile{} }
 func (*UploadFile) ProtoMessage() {}
 func (*UploadFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{28}
+	return fileDescriptor_5c55f6b914d72f56, []int{34}
 }
 func (m *UploadFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -858,7 +1026,7 @@ var xxx_messageInfo_UploadFile proto.InternalMessageInfo
 func (m *WriteFile) Reset()      { *m = WriteFile{} }
 func (*WriteFile) ProtoMessage() {}
 func (*WriteFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c55f6b914d72f56, []int{29}
+	return fileDescriptor_5c55f6b914d72f56, []int{35}
 }
 func (m *WriteFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -884,9 +1052,13 @@ func (m *WriteFile) XXX_DiscardUnknown() {
 var xxx_messageInfo_WriteFile proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterType((*Audit)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.Audit")
+	proto.RegisterType((*AuditChange)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.AuditChange")
 	proto.RegisterType((*CompleteStepRequest)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.CompleteStepRequest")
 	proto.RegisterType((*CompleteStepResponse)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.CompleteStepResponse")
 	proto.RegisterType((*Group)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.Group")
+	proto.RegisterType((*ListAuditsRequest)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.ListAuditsRequest")
+	proto.RegisterType((*ListAuditsResponse)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.ListAuditsResponse")
 	proto.RegisterType((*ListGroupNameRequest)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.ListGroupNameRequest")
 	proto.RegisterType((*ListGroupNameResponse)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.ListGroupNameResponse")
 	proto.RegisterType((*ListNamespaceRequest)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.ListNamespaceRequest")
@@ -905,6 +1077,8 @@ func init() {
 	proto.RegisterType((*Request)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.Request")
 	proto.RegisterType((*Response)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.Response")
 	proto.RegisterType((*Result)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.Result")
+	proto.RegisterType((*RollbackRequest)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.RollbackRequest")
+	proto.RegisterType((*RollbackResponse)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.RollbackResponse")
 	proto.RegisterType((*RunStepRequest)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.RunStepRequest")
 	proto.RegisterType((*RunStepResponse)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.RunStepResponse")
 	proto.RegisterType((*RunnerInfo)(nil), "github.com.Shanghai_Lunara.publisher.pkg.types.RunnerInfo")
@@ -923,100 +1097,236 @@ func init() {
 }
 
 var fileDescriptor_5c55f6b914d72f56 = []byte{
-	// 1487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4f, 0x6f, 0x1c, 0xc5,
-	0x12, 0xf7, 0xcc, 0xce, 0xda, 0xbb, 0xb5, 0x6b, 0xc7, 0x1e, 0x3b, 0xd1, 0xc8, 0x8a, 0xd6, 0xab,
-	0x91, 0xde, 0x93, 0xa3, 0xf7, 0xb2, 0x96, 0xac, 0x08, 0x4c, 0x84, 0xac, 0x64, 0x9d, 0x00, 0x96,
-	0x9c, 0x60, 0xf5, 0x3a, 0x41, 0x70, 0x81, 0xf6, 0x6e, 0x67, 0x76, 0xe4, 0xdd, 0x99, 0xc9, 0x74,
-	0x8f, 0x91, 0x05, 0x12, 0xdc, 0x38, 0xc2, 0x31, 0x12, 0xe2, 0xc6, 0x81, 0x6f, 0xc1, 0x35, 0x17,
-	0x50, 0x8e, 0xb9, 0x60, 0x91, 0xe5, 0x33, 0x70, 0xf1, 0x09, 0x75, 0x4d, 0xcf, 0xbf, 0x4d, 0x00,
-	0xaf, 0x4d, 0x24, 0x22, 0xe5, 0xe4, 0xe9, 0xaa, 0xfa, 0x55, 0x55, 0xff, 0xba, 0xaa, 0xba, 0xbd,
-	0xb0, 0xe9, 0xb8, 0xa2, 0x1f, 0xed, 0xb7, 0xba, 0xfe, 0x70, 0xad, 0xd3, 0xa7, 0x9e, 0xd3, 0xa7,
-	0xee, 0xd5, 0x9d, 0xc8, 0xa3, 0x21, 0x5d, 0x0b, 0xa2, 0xfd, 0x81, 0xcb, 0xfb, 0x2c, 0x5c, 0x0b,
-	0x0e, 0x9c, 0x35, 0x71, 0x14, 0x30, 0xbe, 0xe6, 0x30, 0x8f, 0x85, 0x54, 0xb0, 0x5e, 0x2b, 0x08,
-	0x7d, 0xe1, 0x9b, 0xad, 0x0c, 0xdf, 0x4a, 0xf0, 0x1f, 0xc7, 0xf8, 0x56, 0x8a, 0x6f, 0x05, 0x07,
-	0x4e, 0x0b, 0xf1, 0xcb, 0x57, 0x73, 0xf1, 0x1c, 0xdf, 0xf1, 0xd7, 0xd0, 0xcd, 0x7e, 0xf4, 0x00,
-	0x57, 0xb8, 0xc0, 0xaf, 0xd8, 0xbd, 0xfd, 0x9d, 0x0e, 0x8b, 0x5b, 0xfe, 0x30, 0x18, 0x30, 0xc1,
-	0x3a, 0x82, 0x05, 0x84, 0x3d, 0x8c, 0x18, 0x17, 0xe6, 0xdb, 0x50, 0xf5, 0xe8, 0x90, 0xf1, 0x80,
-	0x76, 0x99, 0xa5, 0x35, 0xb5, 0xd5, 0x6a, 0xbb, 0xf1, 0xf8, 0x78, 0x65, 0x6a, 0x74, 0xbc, 0x52,
-	0xbd, 0x9b, 0x28, 0x4e, 0xf2, 0x0b, 0x92, 0x01, 0x24, 0xda, 0x09, 0xfd, 0x28, 0x90, 0x4a, 0x4b,
-	0x2f, 0xa2, 0xdf, 0x4d, 0x14, 0x27, 0xf9, 0x05, 0xc9, 0x00, 0xe6, 0x3a, 0x40, 0x18, 0x79, 0x1e,
-	0x0b, 0x11, 0x5e, 0x42, 0xb8, 0xa9, 0xe0, 0x40, 0x52, 0x0d, 0xc9, 0x59, 0x99, 0xf7, 0xc1, 0xe0,
-	0x82, 0x05, 0x96, 0xd1, 0xd4, 0x56, 0x6b, 0xeb, 0xd7, 0x26, 0x64, 0xad, 0x25, 0xb7, 0xde, 0xae,
-	0xab, 0x18, 0x06, 0x12, 0x81, 0xfe, 0xec, 0x4b, 0xb0, 0x54, 0xa4, 0x87, 0x07, 0xbe, 0xc7, 0x99,
-	0xed, 0x41, 0x19, 0x73, 0x37, 0x19, 0xcc, 0xc4, 0x69, 0x70, 0x4b, 0x6f, 0x96, 0x56, 0x6b, 0xeb,
-	0xd7, 0x27, 0x8d, 0x1d, 0xef, 0x68, 0xdb, 0x7b, 0xe0, 0xb7, 0x2f, 0xa8, 0x0c, 0x66, 0x62, 0x19,
-	0x27, 0x89, 0x6f, 0x7b, 0x0f, 0x96, 0x76, 0x5c, 0x2e, 0x32, 0xbe, 0xfe, 0x89, 0x73, 0xb2, 0x37,
-	0xe0, 0xe2, 0x98, 0xd7, 0x78, 0x7b, 0xe6, 0x0a, 0x94, 0x5d, 0xc1, 0x86, 0xdc, 0xd2, 0x9a, 0xa5,
-	0xd5, 0x6a, 0xbb, 0x3a, 0x3a, 0x5e, 0x29, 0x6f, 0x4b, 0x01, 0x89, 0xe5, 0x92, 0x17, 0x89, 0xcc,
-	0xbc, 0xc6, 0xf9, 0x24, 0x1e, 0x73, 0xf2, 0xd3, 0x7a, 0xfc, 0x51, 0x07, 0x53, 0x42, 0x09, 0xeb,
-	0xfa, 0x61, 0x8f, 0xbf, 0xaa, 0x85, 0xd8, 0x04, 0x23, 0xa0, 0x0e, 0xc3, 0x42, 0x2c, 0x67, 0x25,
-	0xb5, 0x4b, 0x1d, 0x46, 0x50, 0x63, 0xfe, 0x17, 0xa6, 0x07, 0xcc, 0x73, 0x44, 0xdf, 0x2a, 0xa3,
-	0xcd, 0x9c, 0xb2, 0x99, 0xde, 0x41, 0x29, 0x51, 0x5a, 0x73, 0x0d, 0xaa, 0x2e, 0xbf, 0xcf, 0x42,
-	0xee, 0xfa, 0x9e, 0x35, 0x8d, 0xa6, 0x0b, 0x49, 0xee, 0xdb, 0x89, 0x82, 0x64, 0x36, 0xf6, 0xf7,
-	0x3a, 0x2c, 0x16, 0x18, 0x54, 0xd4, 0x07, 0xe3, 0x14, 0xd6, 0xd6, 0xdb, 0x93, 0x16, 0xe9, 0xf3,
-	0x27, 0x93, 0xe5, 0xbd, 0x4b, 0x43, 0x3a, 0xe4, 0x79, 0xda, 0x29, 0xcc, 0x84, 0xb1, 0xb1, 0x6a,
-	0x8a, 0x37, 0x26, 0x6e, 0x0a, 0x84, 0xe7, 0x1a, 0x42, 0xc5, 0x4e, 0xfc, 0x9a, 0x1b, 0x50, 0x8f,
-	0x3f, 0xef, 0x46, 0xc3, 0x7d, 0x16, 0xe2, 0xe9, 0x94, 0xdb, 0x4b, 0xca, 0xbe, 0x4e, 0x72, 0x3a,
-	0x52, 0xb0, 0xb4, 0xbf, 0xd6, 0x60, 0x01, 0xb7, 0x83, 0x87, 0xf6, 0x2f, 0xa8, 0x33, 0xfb, 0x33,
-	0x55, 0xf9, 0x2a, 0x21, 0x75, 0x6c, 0xb9, 0xc9, 0xa2, 0xbd, 0xc4, 0xc9, 0xf2, 0x48, 0x87, 0xf9,
-	0x1d, 0xdf, 0xe9, 0x88, 0x90, 0xd1, 0xe1, 0xab, 0xda, 0x75, 0xff, 0x87, 0x8a, 0x1c, 0xd7, 0x88,
-	0x30, 0x10, 0x31, 0xaf, 0x10, 0x95, 0x8e, 0x92, 0x93, 0xd4, 0x42, 0x76, 0xa0, 0x1f, 0x89, 0x20,
-	0x12, 0xd8, 0x81, 0xd5, 0xac, 0x92, 0xdf, 0x47, 0x29, 0x51, 0x5a, 0x7b, 0x11, 0x16, 0x72, 0xcc,
-	0xa8, 0xc9, 0x3f, 0x0b, 0xb5, 0x5d, 0xd7, 0x73, 0x92, 0x81, 0x37, 0x07, 0xf5, 0x5d, 0x5f, 0x2e,
-	0x95, 0xfa, 0x17, 0x1d, 0xa6, 0xe3, 0xe2, 0x33, 0x97, 0x41, 0x77, 0x7b, 0xc8, 0x5e, 0xb9, 0x0d,
-	0x2a, 0x84, 0xbe, 0xdd, 0x23, 0xba, 0xdb, 0x2b, 0x12, 0xac, 0x9f, 0x8b, 0xe0, 0xd2, 0xf9, 0x08,
-	0x36, 0x4e, 0x45, 0xf0, 0x6a, 0x4c, 0xb0, 0xac, 0x25, 0x24, 0xad, 0xde, 0xae, 0x27, 0xe4, 0x4a,
-	0x19, 0x49, 0xb5, 0xc9, 0x51, 0xec, 0x1d, 0x05, 0xcc, 0xaa, 0xe0, 0xde, 0x0b, 0x47, 0x21, 0xe5,
-	0x24, 0xb5, 0x90, 0x43, 0xae, 0x1b, 0x32, 0xf9, 0xde, 0xd9, 0xbb, 0x63, 0xcd, 0x14, 0x87, 0xdc,
-	0x56, 0xa2, 0x20, 0x99, 0x8d, 0xfd, 0x95, 0x06, 0x17, 0x09, 0x73, 0x5c, 0x2e, 0x58, 0x58, 0xec,
-	0x60, 0x2f, 0xd9, 0x16, 0x26, 0x19, 0xcf, 0xb9, 0xf3, 0xb4, 0xcc, 0x18, 0x25, 0xb8, 0xcd, 0x5c,
-	0x04, 0xdb, 0x82, 0x4b, 0xe3, 0x89, 0xa8, 0x1a, 0xf8, 0x02, 0x66, 0x92, 0xa4, 0xee, 0x83, 0x21,
-	0x1d, 0xab, 0x74, 0x26, 0x7e, 0x97, 0x48, 0x8e, 0xb2, 0x4b, 0x04, 0xb9, 0x43, 0x7f, 0xe6, 0x65,
-	0x30, 0x7a, 0x54, 0x50, 0x2c, 0x9d, 0x7a, 0xbb, 0x22, 0xb5, 0xb7, 0xa8, 0xa0, 0x04, 0xa5, 0xf6,
-	0x4f, 0x1a, 0x54, 0xd2, 0x39, 0xd2, 0x04, 0xa3, 0xeb, 0xf7, 0x98, 0x2a, 0xc4, 0xd4, 0xd9, 0x96,
-	0xdf, 0x63, 0x04, 0x35, 0xe6, 0x15, 0x98, 0x19, 0x32, 0xce, 0xe5, 0xb5, 0x15, 0x97, 0x62, 0x3a,
-	0x2d, 0xee, 0xc4, 0x62, 0x92, 0xe8, 0xd3, 0xfd, 0x94, 0x5e, 0xd2, 0x7e, 0x8c, 0x17, 0xee, 0xe7,
-	0x8a, 0xec, 0x29, 0x1e, 0x0d, 0xc4, 0xdf, 0x3f, 0x23, 0x1e, 0xe9, 0x30, 0x47, 0x22, 0xef, 0xf5,
-	0x5b, 0xf6, 0xf9, 0xb7, 0xec, 0x02, 0x5c, 0x48, 0x99, 0x51, 0x95, 0xfa, 0xbb, 0x0e, 0xb9, 0xf2,
-	0x96, 0xa5, 0x22, 0x37, 0xae, 0x48, 0x4a, 0x7d, 0x60, 0x86, 0xa8, 0x91, 0xdd, 0xdd, 0xf7, 0xb9,
-	0xf0, 0x32, 0x32, 0xd2, 0xee, 0x7e, 0x4f, 0xc9, 0x49, 0x6a, 0x51, 0x64, 0xbe, 0x74, 0x2e, 0xe6,
-	0x8d, 0x49, 0x99, 0xbf, 0x91, 0x30, 0x8f, 0x93, 0x28, 0x1e, 0xf4, 0xcd, 0x22, 0xf3, 0x52, 0x73,
-	0x52, 0x58, 0x91, 0x1c, 0xc6, 0xfc, 0x10, 0xca, 0x92, 0x37, 0x6e, 0x4d, 0xe3, 0xf5, 0x7b, 0xb6,
-	0x83, 0x98, 0x55, 0x21, 0xcb, 0x72, 0xc5, 0x49, 0xec, 0xd1, 0xfe, 0xb9, 0x02, 0x78, 0x32, 0x7f,
-	0x79, 0x47, 0x24, 0xa7, 0xa1, 0xff, 0xe9, 0x69, 0xac, 0xc3, 0x34, 0x17, 0x54, 0x44, 0x5c, 0x91,
-	0xbb, 0x9c, 0x04, 0xdb, 0xed, 0x53, 0x8e, 0xd4, 0xc8, 0x20, 0xb8, 0x20, 0xca, 0xd2, 0xbc, 0x06,
-	0xd3, 0x81, 0x3f, 0x70, 0xbb, 0x47, 0x8a, 0xd2, 0xcb, 0xe9, 0x33, 0x0e, 0xa5, 0x92, 0x0f, 0x04,
-	0xe1, 0x8a, 0x28, 0x5b, 0xf3, 0x06, 0x54, 0xe9, 0x21, 0x75, 0x07, 0x74, 0x7f, 0x90, 0x90, 0x69,
-	0x27, 0x67, 0x71, 0x33, 0x51, 0x9c, 0x1c, 0xaf, 0xcc, 0x4a, 0x6c, 0x2a, 0x20, 0x19, 0xc8, 0xfc,
-	0x04, 0x0c, 0xe6, 0x1d, 0x26, 0x64, 0x6e, 0x9e, 0x85, 0xcc, 0xd6, 0x6d, 0xef, 0x90, 0xdf, 0xf6,
-	0x44, 0x78, 0x94, 0xb1, 0x21, 0x45, 0x04, 0x3d, 0x9b, 0x76, 0x7a, 0xad, 0xcf, 0xe0, 0x70, 0x80,
-	0xe7, 0xaf, 0x74, 0xf3, 0x21, 0xd4, 0xa2, 0x60, 0xe0, 0xd3, 0xde, 0x3b, 0xee, 0x80, 0x71, 0xab,
-	0x72, 0xb6, 0x87, 0xd5, 0xbd, 0xd4, 0x45, 0x7b, 0x51, 0x25, 0x52, 0xcb, 0x64, 0x9c, 0xe4, 0x63,
-	0x98, 0x43, 0x80, 0x4f, 0x43, 0x57, 0xb0, 0x38, 0x62, 0x15, 0x23, 0xbe, 0x35, 0x69, 0xc4, 0x0f,
-	0x12, 0x0f, 0xd9, 0xf4, 0x48, 0x45, 0x9c, 0xe4, 0x02, 0xc8, 0x9b, 0x5a, 0x0d, 0x6b, 0x6e, 0x01,
-	0xf2, 0x80, 0x37, 0xb5, 0x9a, 0xe4, 0x9c, 0xa4, 0xda, 0xb1, 0xd9, 0x54, 0x3b, 0xd5, 0x6c, 0xda,
-	0x80, 0x7a, 0x2f, 0x0a, 0xa9, 0x70, 0x7d, 0x6f, 0xdb, 0xbb, 0xc3, 0xad, 0x7a, 0xf1, 0xd9, 0x7d,
-	0x2b, 0xd3, 0x75, 0x48, 0xc1, 0xd2, 0xfc, 0x8f, 0xfc, 0x9f, 0x60, 0x48, 0xc3, 0x03, 0x6e, 0xcd,
-	0x62, 0x5a, 0xb5, 0xf8, 0x5d, 0x8f, 0x22, 0x92, 0xe8, 0xcc, 0xcf, 0xa1, 0xc6, 0xfb, 0x34, 0x74,
-	0x3d, 0x47, 0xce, 0x7f, 0x6b, 0x0e, 0xe9, 0xba, 0x7d, 0xa6, 0x6a, 0xe9, 0x64, 0x7e, 0xe2, 0xa2,
-	0x49, 0xcf, 0x2a, 0xa7, 0x21, 0xf9, 0x70, 0xe6, 0x26, 0xcc, 0xa9, 0x65, 0x87, 0x09, 0xe1, 0x7a,
-	0x8e, 0x75, 0xa1, 0xa9, 0xad, 0x56, 0xda, 0x97, 0x14, 0x72, 0xae, 0x53, 0xd0, 0x92, 0x31, 0xeb,
-	0xe5, 0x37, 0xa1, 0x9a, 0xd6, 0xa8, 0x39, 0x0f, 0xa5, 0x03, 0x76, 0x14, 0x0f, 0x53, 0x22, 0x3f,
-	0xcd, 0x25, 0x28, 0x1f, 0xd2, 0x41, 0xa4, 0x5a, 0x9a, 0xc4, 0x8b, 0xeb, 0xfa, 0x86, 0xb6, 0xbc,
-	0x09, 0xf3, 0xe3, 0xe9, 0x4e, 0x82, 0xb7, 0x43, 0xc0, 0xdb, 0xd4, 0x5c, 0x05, 0x63, 0xdf, 0xef,
-	0x29, 0x50, 0x7a, 0x2e, 0x46, 0xdb, 0xef, 0xc9, 0xce, 0xc6, 0xbf, 0x04, 0x2d, 0xe4, 0x7c, 0xe4,
-	0x2c, 0x3c, 0x74, 0xbb, 0xec, 0x66, 0xe0, 0xaa, 0x19, 0x93, 0xce, 0xc7, 0x8e, 0xd2, 0xec, 0x6e,
-	0x9f, 0x14, 0x56, 0x24, 0x87, 0xb1, 0xbf, 0xd5, 0x61, 0xe1, 0x5e, 0xd0, 0xa3, 0xaf, 0x7f, 0x39,
-	0x7a, 0xd1, 0x6d, 0xbb, 0x04, 0x66, 0x9e, 0x1c, 0x75, 0xe1, 0xfe, 0xa0, 0x01, 0x64, 0x93, 0x42,
-	0x26, 0xcc, 0xfd, 0x28, 0xec, 0x62, 0xef, 0x2a, 0xb6, 0xd2, 0x84, 0x3b, 0xa9, 0x86, 0xe4, 0xac,
-	0x24, 0x46, 0xd0, 0xd0, 0x61, 0x62, 0x97, 0x8a, 0xbe, 0xe2, 0x28, 0xc5, 0xec, 0xa5, 0x1a, 0x92,
-	0xb3, 0xca, 0x30, 0x18, 0xa7, 0xf4, 0x22, 0x4c, 0x1c, 0x27, 0xb3, 0xb2, 0x1f, 0x40, 0x35, 0x1d,
-	0x31, 0xb2, 0x7b, 0xbb, 0xbe, 0x27, 0x98, 0x27, 0x30, 0xcb, 0x7a, 0xdc, 0xbd, 0x5b, 0xb1, 0x88,
-	0x24, 0xba, 0xb1, 0x38, 0xfa, 0x69, 0xe2, 0xb4, 0xff, 0xf7, 0xf8, 0x59, 0x63, 0xea, 0xc9, 0xb3,
-	0xc6, 0xd4, 0xd3, 0x67, 0x8d, 0xa9, 0x2f, 0x47, 0x0d, 0xed, 0xf1, 0xa8, 0xa1, 0x3d, 0x19, 0x35,
-	0xb4, 0xa7, 0xa3, 0x86, 0xf6, 0xeb, 0xa8, 0xa1, 0x7d, 0xf3, 0x5b, 0x63, 0xea, 0xa3, 0x32, 0xd2,
-	0xfd, 0x47, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8f, 0x9f, 0xae, 0x2d, 0x4f, 0x15, 0x00, 0x00,
+	// 1800 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xcd, 0x6f, 0x24, 0x47,
+	0x15, 0x77, 0xf7, 0x74, 0xcf, 0x78, 0xde, 0xcc, 0x7a, 0xed, 0x5a, 0x67, 0xd5, 0xb2, 0xa2, 0x59,
+	0xab, 0x11, 0x91, 0x23, 0xc8, 0x58, 0xb2, 0x12, 0x58, 0x02, 0x5a, 0xd6, 0xe3, 0x6c, 0x60, 0xa4,
+	0xdd, 0x30, 0xaa, 0x71, 0x16, 0x81, 0x84, 0xa0, 0x66, 0xa6, 0xdc, 0xd3, 0xf2, 0x4c, 0x77, 0xa7,
+	0xab, 0xda, 0xc8, 0x02, 0x29, 0xdc, 0x38, 0xc2, 0x05, 0x09, 0x09, 0x71, 0xe3, 0xc0, 0x0d, 0x89,
+	0x7f, 0x80, 0xeb, 0x72, 0x00, 0x45, 0xe2, 0x92, 0x0b, 0x2b, 0xd6, 0xfc, 0x01, 0x9c, 0xb8, 0xf8,
+	0x84, 0xea, 0xa3, 0xab, 0xbb, 0x67, 0x37, 0x1b, 0x8f, 0x9d, 0x48, 0x58, 0xca, 0xc9, 0x53, 0xef,
+	0xb3, 0xea, 0xf7, 0x3e, 0xea, 0x55, 0x1b, 0xee, 0x05, 0x21, 0x9f, 0x66, 0xa3, 0xee, 0x38, 0x9e,
+	0xef, 0x0e, 0xa7, 0x24, 0x0a, 0xa6, 0x24, 0x7c, 0xe3, 0x61, 0x16, 0x91, 0x94, 0xec, 0x26, 0xd9,
+	0x68, 0x16, 0xb2, 0x29, 0x4d, 0x77, 0x93, 0xe3, 0x60, 0x97, 0x9f, 0x26, 0x94, 0xed, 0x06, 0x34,
+	0xa2, 0x29, 0xe1, 0x74, 0xd2, 0x4d, 0xd2, 0x98, 0xc7, 0xa8, 0x5b, 0xe8, 0x77, 0x73, 0xfd, 0x1f,
+	0x2b, 0xfd, 0xae, 0xd1, 0xef, 0x26, 0xc7, 0x41, 0x57, 0xea, 0x6f, 0xbd, 0x51, 0xf2, 0x17, 0xc4,
+	0x41, 0xbc, 0x2b, 0xcd, 0x8c, 0xb2, 0x23, 0xb9, 0x92, 0x0b, 0xf9, 0x4b, 0x99, 0xf7, 0xff, 0xe4,
+	0x80, 0xbb, 0x9f, 0x4d, 0x42, 0x8e, 0xb6, 0xc0, 0x0e, 0x27, 0x9e, 0xb5, 0x6d, 0xed, 0xb8, 0x3d,
+	0x78, 0xf2, 0xf4, 0xce, 0xca, 0xd9, 0xd3, 0x3b, 0x76, 0x7f, 0x82, 0xed, 0x70, 0x82, 0xbe, 0x04,
+	0x2e, 0x19, 0xf3, 0x38, 0xf5, 0xec, 0x6d, 0x6b, 0xa7, 0xd9, 0xbb, 0xa1, 0xd9, 0xee, 0xbe, 0x20,
+	0x62, 0xc5, 0x43, 0x5f, 0x85, 0x55, 0x16, 0x67, 0xe9, 0x98, 0xf6, 0x13, 0xaf, 0x26, 0xe5, 0xd6,
+	0xb5, 0xdc, 0xea, 0x50, 0xd1, 0x07, 0xd8, 0x48, 0xa0, 0xfb, 0x00, 0x8c, 0xa6, 0x27, 0xe1, 0x98,
+	0xee, 0x27, 0xa1, 0xe7, 0x48, 0xf9, 0x6d, 0x2d, 0x0f, 0x43, 0xcd, 0x19, 0xf4, 0xcf, 0x2b, 0x2b,
+	0x5c, 0xd2, 0x41, 0xdf, 0x82, 0x66, 0x44, 0xe6, 0x94, 0x25, 0x64, 0x4c, 0x3d, 0x57, 0x1a, 0xe8,
+	0x68, 0x03, 0xcd, 0xf7, 0x72, 0xc6, 0x79, 0x79, 0x81, 0x0b, 0x05, 0xa1, 0x1d, 0xa4, 0x71, 0x96,
+	0x08, 0xa6, 0x57, 0xaf, 0x6a, 0x7f, 0x27, 0x67, 0x9c, 0x97, 0x17, 0xb8, 0x50, 0x40, 0x7b, 0x00,
+	0x69, 0x16, 0x45, 0x34, 0x95, 0xea, 0x0d, 0xa9, 0x8e, 0xf2, 0xdd, 0x63, 0xc3, 0xc1, 0x25, 0x29,
+	0x89, 0x0f, 0xa7, 0xca, 0xe1, 0xea, 0x02, 0x3e, 0x9a, 0x8e, 0x8d, 0x04, 0x3a, 0x82, 0xc6, 0x58,
+	0x84, 0x9b, 0x32, 0xaf, 0xb9, 0x5d, 0xdb, 0x69, 0xed, 0x7d, 0x73, 0xc9, 0x4c, 0xe8, 0xca, 0xb0,
+	0x1e, 0x48, 0x1b, 0xbd, 0x9b, 0xda, 0x53, 0x43, 0xad, 0x19, 0xce, 0x8d, 0xa3, 0x5d, 0x68, 0x8e,
+	0x53, 0x2a, 0x12, 0xee, 0xf0, 0x91, 0x07, 0x32, 0xfa, 0x1b, 0x39, 0x0e, 0x07, 0x39, 0x03, 0x17,
+	0x32, 0xfe, 0x87, 0xd0, 0x2a, 0x59, 0x16, 0xa9, 0x71, 0x14, 0xd2, 0x99, 0xca, 0x9c, 0x52, 0x6a,
+	0xbc, 0x2b, 0x88, 0x58, 0xf1, 0xd0, 0x6b, 0x50, 0x1f, 0xd1, 0xa3, 0x38, 0xa5, 0x3a, 0x81, 0xd6,
+	0xb4, 0x54, 0xbd, 0x27, 0xa9, 0x58, 0x73, 0x65, 0x9e, 0x1d, 0x71, 0x9a, 0x7a, 0xb5, 0xaa, 0xb1,
+	0x7d, 0x41, 0xc4, 0x8a, 0xe7, 0xff, 0xde, 0x86, 0x5b, 0x07, 0xf1, 0x3c, 0x99, 0x51, 0x4e, 0x05,
+	0x70, 0x98, 0x7e, 0x90, 0x51, 0xc6, 0xab, 0xf9, 0x60, 0x5d, 0x29, 0x1f, 0xec, 0xab, 0xe5, 0x43,
+	0xed, 0x42, 0xf9, 0xf0, 0x18, 0x1c, 0x11, 0x6d, 0x99, 0xfb, 0xad, 0xbd, 0x37, 0x97, 0x0d, 0xaf,
+	0x38, 0x7a, 0xaf, 0xad, 0x7d, 0x38, 0x62, 0x85, 0xa5, 0x3d, 0xff, 0x36, 0x6c, 0x56, 0xe1, 0x61,
+	0x49, 0x1c, 0x31, 0xea, 0x47, 0xe0, 0xca, 0xbd, 0x23, 0x0a, 0x0d, 0xb5, 0x0d, 0xe6, 0xd9, 0x32,
+	0xb5, 0xde, 0x5e, 0xd6, 0xb7, 0x3a, 0x51, 0x3f, 0x3a, 0x8a, 0x8b, 0xcc, 0x52, 0x34, 0x86, 0x73,
+	0xdb, 0xfe, 0x9f, 0x6d, 0xd8, 0x78, 0x18, 0x32, 0x2e, 0xb3, 0x85, 0x5d, 0xd7, 0x28, 0x99, 0xd6,
+	0xe7, 0xbc, 0xa4, 0xf5, 0x6d, 0x83, 0x93, 0x90, 0x40, 0x75, 0x21, 0xb7, 0x08, 0xca, 0x80, 0x04,
+	0x14, 0x4b, 0x8e, 0xa8, 0x80, 0x19, 0x8d, 0x02, 0x3e, 0x95, 0xbd, 0xc6, 0x2d, 0x2a, 0xe0, 0xa1,
+	0xa4, 0x62, 0xcd, 0xf5, 0x7f, 0x63, 0x03, 0x2a, 0x83, 0xa6, 0x62, 0x87, 0x42, 0xa8, 0x27, 0x24,
+	0x25, 0x73, 0x26, 0x21, 0x6b, 0xed, 0xed, 0x2f, 0x1b, 0xb1, 0xe7, 0x02, 0x51, 0xec, 0x60, 0x20,
+	0x0d, 0x63, 0xed, 0x00, 0xfd, 0x08, 0xea, 0x44, 0x0a, 0xea, 0xe4, 0x78, 0xeb, 0x52, 0x7d, 0xa7,
+	0x30, 0xaf, 0xbd, 0x6a, 0xa3, 0xe8, 0x2d, 0x68, 0xc9, 0x5f, 0xef, 0x65, 0xf3, 0x91, 0x2e, 0x74,
+	0xb7, 0x77, 0x4b, 0x0b, 0xb7, 0xf6, 0x0b, 0x16, 0x2e, 0xcb, 0xf9, 0x87, 0xb0, 0x29, 0x8e, 0x50,
+	0x84, 0xf5, 0xb3, 0x48, 0x27, 0xff, 0x2e, 0xbc, 0xb2, 0x60, 0x55, 0xe3, 0x7d, 0x07, 0xdc, 0x90,
+	0x53, 0x09, 0x77, 0x6d, 0xa7, 0xd9, 0x6b, 0x8a, 0x88, 0xf7, 0x05, 0x01, 0x2b, 0xba, 0x28, 0x32,
+	0xa1, 0x59, 0x58, 0x55, 0xfb, 0xc9, 0x2d, 0x96, 0xe8, 0x17, 0xb5, 0xf8, 0x17, 0x1d, 0x79, 0x4c,
+	0xc7, 0x71, 0x3a, 0xb9, 0xb6, 0xf5, 0x92, 0x97, 0x82, 0x73, 0x81, 0x52, 0x70, 0x5f, 0x56, 0x0a,
+	0xe2, 0x66, 0x0a, 0xd9, 0x63, 0x9a, 0xb2, 0x30, 0x8e, 0xbc, 0x7a, 0xf5, 0x66, 0xea, 0xe7, 0x0c,
+	0x5c, 0xc8, 0xf8, 0x7f, 0xb0, 0xe1, 0x56, 0x05, 0x41, 0x0d, 0x7d, 0xb2, 0x08, 0x61, 0x6b, 0xaf,
+	0x77, 0x99, 0xfa, 0xa9, 0x46, 0xe6, 0xb9, 0x02, 0x2a, 0xc1, 0x4e, 0xa0, 0x91, 0x2a, 0x61, 0x5d,
+	0x44, 0x5f, 0x5b, 0xba, 0xc3, 0x4a, 0xf5, 0x52, 0x77, 0xd5, 0xbe, 0x73, 0xbb, 0xe8, 0x2e, 0xb4,
+	0xd5, 0xcf, 0x4a, 0x21, 0x6d, 0x6a, 0xf9, 0x36, 0x2e, 0xf1, 0x70, 0x45, 0xd2, 0xff, 0x95, 0xa5,
+	0xfa, 0xb2, 0x0a, 0xe0, 0xff, 0x41, 0x9e, 0xf9, 0x3f, 0x03, 0x54, 0xde, 0x90, 0x0e, 0x5b, 0xe9,
+	0x9a, 0xb2, 0x3e, 0xc7, 0x6b, 0xea, 0xaf, 0x36, 0xac, 0x3f, 0x8c, 0x83, 0x21, 0x4f, 0x29, 0x99,
+	0x5f, 0xd7, 0xaa, 0x2b, 0xcf, 0x96, 0xce, 0xa7, 0xce, 0x96, 0xaf, 0x41, 0x3d, 0xce, 0x78, 0x92,
+	0x71, 0x3d, 0x36, 0x9b, 0x4c, 0xfe, 0x9e, 0xa4, 0x62, 0xcd, 0x15, 0x15, 0xc8, 0xc3, 0x39, 0x65,
+	0x9c, 0xcc, 0x13, 0x59, 0x81, 0xb5, 0xa2, 0x02, 0x0f, 0x73, 0x06, 0x2e, 0x64, 0xfc, 0x5b, 0xb0,
+	0x51, 0x82, 0x52, 0xcf, 0x1d, 0x37, 0xa0, 0x35, 0x08, 0xa3, 0x20, 0xef, 0x90, 0x6b, 0xd0, 0x1e,
+	0xc4, 0x51, 0x60, 0xd8, 0xff, 0xb4, 0xa1, 0xae, 0xb2, 0xf5, 0xa5, 0x4f, 0x90, 0x4a, 0x44, 0xec,
+	0x2b, 0x45, 0xa4, 0x76, 0xb5, 0x88, 0x38, 0x17, 0x8a, 0xc8, 0x8e, 0x8a, 0x88, 0x48, 0x3e, 0x89,
+	0x72, 0xbb, 0xd7, 0xce, 0xa3, 0x21, 0x68, 0xd8, 0x70, 0xf3, 0xd8, 0x1d, 0x9e, 0x26, 0xea, 0x5d,
+	0xe0, 0x56, 0x63, 0x27, 0xe8, 0xd8, 0x48, 0x54, 0xe7, 0xf5, 0xc6, 0x05, 0xe6, 0xf5, 0x5f, 0x5a,
+	0xf0, 0x0a, 0xa6, 0x41, 0xc8, 0xc4, 0x08, 0x5d, 0x29, 0xf9, 0x28, 0x3f, 0x96, 0xdc, 0xa4, 0x6a,
+	0x8c, 0x57, 0xa9, 0xb1, 0x05, 0x48, 0xe4, 0x31, 0x4b, 0x1e, 0x7c, 0x0f, 0x6e, 0x2f, 0x6e, 0x44,
+	0xe7, 0xc0, 0x87, 0xd0, 0xc8, 0x37, 0xf5, 0x18, 0x1c, 0x61, 0xd8, 0xb3, 0x2e, 0x37, 0x15, 0x0b,
+	0x8c, 0x8a, 0x5b, 0x47, 0xac, 0xb0, 0xb4, 0x87, 0x5e, 0x05, 0x67, 0x42, 0x38, 0x91, 0xa9, 0xd3,
+	0xee, 0xad, 0x0a, 0xee, 0x3b, 0x84, 0x13, 0x2c, 0xa9, 0xfe, 0xdf, 0x2c, 0x58, 0x35, 0x8d, 0x67,
+	0x1b, 0x9c, 0x71, 0x3c, 0xa1, 0x3a, 0x11, 0x8d, 0xb1, 0x83, 0x78, 0x42, 0xb1, 0xe4, 0xa0, 0xd7,
+	0xa1, 0x31, 0xa7, 0x8c, 0x89, 0x7b, 0x4e, 0xa5, 0xa2, 0x69, 0x2f, 0x8f, 0x14, 0x19, 0xe7, 0x7c,
+	0x73, 0x9e, 0xda, 0xe7, 0x74, 0x1e, 0xe7, 0x85, 0xe7, 0x79, 0x5d, 0xd4, 0x14, 0xcb, 0x66, 0xfc,
+	0xd3, 0xe7, 0x8e, 0xff, 0x58, 0x70, 0x13, 0xc7, 0xb3, 0xd9, 0x88, 0x8c, 0x8f, 0xaf, 0x71, 0xfb,
+	0x53, 0x57, 0x5c, 0x7f, 0xe2, 0x39, 0xd5, 0x12, 0xc2, 0x9a, 0x8e, 0x8d, 0x84, 0xff, 0x0f, 0x0b,
+	0xd6, 0x8b, 0x13, 0xeb, 0xa0, 0x07, 0x0b, 0x13, 0xf6, 0xb7, 0x97, 0x2e, 0x84, 0x2a, 0x86, 0x9f,
+	0x38, 0x5f, 0xe7, 0xcf, 0x3e, 0xfb, 0x33, 0x7e, 0xf6, 0xfd, 0xd6, 0x86, 0x35, 0x9c, 0x45, 0x5f,
+	0xbc, 0x88, 0x9f, 0x87, 0x66, 0x03, 0x6e, 0x1a, 0x64, 0x74, 0xc7, 0xf9, 0xaf, 0x0d, 0xa5, 0x36,
+	0x25, 0x4a, 0x5e, 0x1c, 0x5c, 0x83, 0x64, 0x6c, 0xc8, 0x1d, 0x3a, 0x91, 0x4e, 0xb1, 0x69, 0xcc,
+	0x78, 0x54, 0x80, 0x61, 0x52, 0xec, 0xbb, 0x9a, 0x8e, 0x8d, 0x44, 0x15, 0xf9, 0xda, 0x95, 0x90,
+	0x77, 0x96, 0x45, 0xfe, 0x7e, 0x8e, 0xbc, 0xbc, 0x51, 0xdc, 0xea, 0x97, 0x35, 0x6c, 0x38, 0xe7,
+	0x95, 0x15, 0x2e, 0xe9, 0xa0, 0x1f, 0x80, 0x2b, 0x70, 0x63, 0x5e, 0x7d, 0xbb, 0x76, 0xe9, 0x40,
+	0x98, 0x97, 0xb2, 0x58, 0x31, 0xac, 0x2c, 0xfa, 0x7f, 0x5f, 0x05, 0x19, 0x99, 0x97, 0xde, 0xf5,
+	0x79, 0x34, 0xec, 0x4f, 0x8c, 0xc6, 0x1e, 0xd4, 0x19, 0x27, 0x3c, 0x63, 0x1a, 0xdc, 0xad, 0xdc,
+	0xd9, 0x60, 0x4a, 0x98, 0x84, 0x46, 0x38, 0x91, 0x0b, 0xac, 0x25, 0xd1, 0x9b, 0x50, 0x4f, 0xe2,
+	0x59, 0x38, 0x3e, 0xd5, 0x90, 0xbe, 0x6a, 0x0a, 0x54, 0x52, 0xe5, 0x97, 0x46, 0xa1, 0x24, 0x57,
+	0x58, 0xcb, 0xa2, 0xfb, 0xd0, 0x24, 0x27, 0x24, 0x9c, 0x91, 0xd1, 0x2c, 0x07, 0xd3, 0xcf, 0x63,
+	0xb1, 0x9f, 0x33, 0xce, 0x9f, 0xde, 0xb9, 0x21, 0x74, 0x0d, 0x01, 0x17, 0x4a, 0xe8, 0x27, 0xe0,
+	0xd0, 0xe8, 0x24, 0x07, 0xf3, 0xde, 0x65, 0xc0, 0xec, 0x3e, 0x88, 0x4e, 0xd8, 0x83, 0x88, 0xa7,
+	0xa7, 0x05, 0x1a, 0x82, 0x84, 0xa5, 0x65, 0xe4, 0x9b, 0x79, 0xae, 0x21, 0x9b, 0x3c, 0xbc, 0x60,
+	0x96, 0xfb, 0x00, 0x5a, 0x59, 0x32, 0x8b, 0xc9, 0xe4, 0xdd, 0x70, 0x46, 0x99, 0xb7, 0x7a, 0xb9,
+	0x89, 0xfa, 0x7d, 0x63, 0xa2, 0x78, 0xb3, 0x17, 0x34, 0x86, 0xcb, 0x3e, 0xd0, 0x1c, 0xe0, 0xa7,
+	0x69, 0xc8, 0xa9, 0xf2, 0xa8, 0xbe, 0x62, 0x7e, 0x63, 0x59, 0x8f, 0xdf, 0xcf, 0x2d, 0x14, 0xdd,
+	0xc3, 0x90, 0x18, 0x2e, 0x39, 0x10, 0x13, 0x97, 0xbe, 0x74, 0x99, 0x07, 0x12, 0x07, 0x39, 0x71,
+	0xe9, 0x1b, 0x99, 0x61, 0xc3, 0x5d, 0xe8, 0x4d, 0xad, 0x0b, 0xf5, 0xa6, 0xbb, 0xd0, 0x9e, 0x64,
+	0x29, 0xe1, 0x61, 0x1c, 0xf5, 0xa3, 0x47, 0xcc, 0x6b, 0x57, 0xdf, 0x5b, 0xef, 0x14, 0xbc, 0x21,
+	0xae, 0x48, 0xa2, 0x2f, 0x8b, 0xc7, 0xe0, 0x9c, 0xa4, 0xc7, 0xcc, 0xbb, 0x21, 0xb7, 0xd5, 0x52,
+	0x0f, 0x3a, 0x49, 0xc2, 0x39, 0x0f, 0xfd, 0x1c, 0x5a, 0x6c, 0x4a, 0xd2, 0x30, 0x0a, 0xc4, 0x3d,
+	0xee, 0xad, 0x49, 0xb8, 0x1e, 0x5c, 0x2a, 0x5b, 0x86, 0x85, 0x1d, 0x95, 0x34, 0x26, 0x56, 0x25,
+	0x0e, 0x2e, 0xbb, 0x43, 0xf7, 0x60, 0x4d, 0x2f, 0x87, 0x94, 0xf3, 0x30, 0x0a, 0xbc, 0x9b, 0xdb,
+	0xd6, 0xce, 0x6a, 0xef, 0xb6, 0xd6, 0x5c, 0x1b, 0x56, 0xb8, 0x78, 0x41, 0x7a, 0xeb, 0xeb, 0xd0,
+	0x34, 0x39, 0x8a, 0xd6, 0xa1, 0x76, 0x4c, 0x4f, 0x55, 0x33, 0xc5, 0xe2, 0x27, 0xda, 0x04, 0xf7,
+	0x84, 0xcc, 0x32, 0x5d, 0xd2, 0x58, 0x2d, 0xde, 0xb6, 0xef, 0x5a, 0x5b, 0xf7, 0x60, 0x7d, 0x71,
+	0xbb, 0xcb, 0xe8, 0xfb, 0x29, 0xc8, 0xa9, 0x08, 0xed, 0x80, 0x33, 0x8a, 0x27, 0x5a, 0xc9, 0xc4,
+	0xc5, 0xe9, 0xc5, 0x93, 0xd3, 0x73, 0xfd, 0x17, 0x4b, 0x89, 0x85, 0xff, 0x3c, 0xd8, 0xcb, 0xff,
+	0xe7, 0xc1, 0xff, 0x9d, 0x0d, 0x1b, 0xef, 0x27, 0x13, 0xf2, 0xc5, 0xf7, 0xe7, 0x17, 0xdd, 0xb6,
+	0x9b, 0x80, 0xca, 0xe0, 0xe8, 0x0b, 0xf7, 0x8f, 0x16, 0x40, 0xd1, 0x29, 0xc4, 0x86, 0xd5, 0xbf,
+	0x82, 0xc4, 0xca, 0xb3, 0xaa, 0x1b, 0x1e, 0x1a, 0x0e, 0x2e, 0x49, 0x09, 0x1d, 0x4e, 0xd2, 0x80,
+	0xf2, 0x01, 0xe1, 0x53, 0xcf, 0xae, 0xea, 0x1c, 0x1a, 0x0e, 0x2e, 0x49, 0x15, 0x3a, 0xd2, 0x4f,
+	0xed, 0x45, 0x3a, 0xca, 0x4f, 0x21, 0xe5, 0x1f, 0x41, 0xd3, 0xb4, 0x18, 0x51, 0xbd, 0xe3, 0x38,
+	0xe2, 0x34, 0xe2, 0x72, 0x97, 0x6d, 0x55, 0xbd, 0x07, 0x8a, 0x84, 0x73, 0xde, 0x82, 0x1f, 0xfb,
+	0x22, 0x7e, 0x7a, 0x5f, 0x79, 0xf2, 0xac, 0xb3, 0xf2, 0xd1, 0xb3, 0xce, 0xca, 0xc7, 0xcf, 0x3a,
+	0x2b, 0xbf, 0x38, 0xeb, 0x58, 0x4f, 0xce, 0x3a, 0xd6, 0x47, 0x67, 0x1d, 0xeb, 0xe3, 0xb3, 0x8e,
+	0xf5, 0xaf, 0xb3, 0x8e, 0xf5, 0xeb, 0x7f, 0x77, 0x56, 0x7e, 0xe8, 0x4a, 0xb8, 0xff, 0x37, 0x00,
+	0x3a, 0x30, 0x0e, 0x2c, 0x48, 0x1c, 0x00, 0x00,
+}
+
+func (m *Audit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Audit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Audit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.CreatedTM))
+	i--
+	dAtA[i] = 0x50
+	if len(m.Changes) > 0 {
+		for iNdEx := len(m.Changes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Changes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	i -= len(m.StepName)
+	copy(dAtA[i:], m.StepName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.StepName)))
+	i--
+	dAtA[i] = 0x42
+	i -= len(m.RunnerName)
+	copy(dAtA[i:], m.RunnerName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.RunnerName)))
+	i--
+	dAtA[i] = 0x3a
+	i -= len(m.GroupName)
+	copy(dAtA[i:], m.GroupName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.GroupName)))
+	i--
+	dAtA[i] = 0x32
+	i -= len(m.Namespace)
+	copy(dAtA[i:], m.Namespace)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i--
+	dAtA[i] = 0x2a
+	i -= len(m.ServiceAPI)
+	copy(dAtA[i:], m.ServiceAPI)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.ServiceAPI)))
+	i--
+	dAtA[i] = 0x22
+	i -= len(m.SourceIP)
+	copy(dAtA[i:], m.SourceIP)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.SourceIP)))
+	i--
+	dAtA[i] = 0x1a
+	i -= len(m.Actor)
+	copy(dAtA[i:], m.Actor)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Actor)))
+	i--
+	dAtA[i] = 0x12
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Id))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func (m *AuditChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuditChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuditChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.After)
+	copy(dAtA[i:], m.After)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.After)))
+	i--
+	dAtA[i] = 0x1a
+	i -= len(m.Before)
+	copy(dAtA[i:], m.Before)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Before)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Field)
+	copy(dAtA[i:], m.Field)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Field)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
 func (m *CompleteStepRequest) Marshal() (dAtA []byte, err error) {
@@ -1127,7 +1437,7 @@ func (m *Group) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ListGroupNameRequest) Marshal() (dAtA []byte, err error) {
+func (m *ListAuditsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1137,27 +1447,126 @@ func (m *ListGroupNameRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListGroupNameRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListAuditsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListGroupNameRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListAuditsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	i -= len(m.Namespace)
-	copy(dAtA[i:], m.Namespace)
-	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Length))
 	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
-}
-
-func (m *ListGroupNameResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
+	dAtA[i] = 0x30
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Page))
+	i--
+	dAtA[i] = 0x28
+	i -= len(m.Actor)
+	copy(dAtA[i:], m.Actor)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Actor)))
+	i--
+	dAtA[i] = 0x22
+	i -= len(m.RunnerName)
+	copy(dAtA[i:], m.RunnerName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.RunnerName)))
+	i--
+	dAtA[i] = 0x1a
+	i -= len(m.GroupName)
+	copy(dAtA[i:], m.GroupName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.GroupName)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Namespace)
+	copy(dAtA[i:], m.Namespace)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAuditsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAuditsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAuditsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.AuditNumber))
+	i--
+	dAtA[i] = 0x18
+	if len(m.Audits) > 0 {
+		for iNdEx := len(m.Audits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Audits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ListGroupNameRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListGroupNameRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListGroupNameRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.Namespace)
+	copy(dAtA[i:], m.Namespace)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ListGroupNameResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
@@ -1429,6 +1838,9 @@ func (m *LogStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Timestamp))
+	i--
+	dAtA[i] = 0x30
 	i -= len(m.Output)
 	copy(dAtA[i:], m.Output)
 	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Output)))
@@ -1756,6 +2168,90 @@ func (m *Result) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RollbackRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.RecordId))
+	i--
+	dAtA[i] = 0x20
+	i -= len(m.RunnerName)
+	copy(dAtA[i:], m.RunnerName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.RunnerName)))
+	i--
+	dAtA[i] = 0x1a
+	i -= len(m.GroupName)
+	copy(dAtA[i:], m.GroupName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.GroupName)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Namespace)
+	copy(dAtA[i:], m.Namespace)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Namespace)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RollbackResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RollbackResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Step.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *RunStepRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2242,6 +2738,52 @@ func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
+func (m *Audit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovGenerated(uint64(m.Id))
+	l = len(m.Actor)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.SourceIP)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.ServiceAPI)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Namespace)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.GroupName)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.RunnerName)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.StepName)
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	n += 1 + sovGenerated(uint64(m.CreatedTM))
+	return n
+}
+
+func (m *AuditChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Before)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.After)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
 func (m *CompleteStepRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2283,6 +2825,43 @@ func (m *Group) Size() (n int) {
 	return n
 }
 
+func (m *ListAuditsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.GroupName)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.RunnerName)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Actor)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.Page))
+	n += 1 + sovGenerated(uint64(m.Length))
+	return n
+}
+
+func (m *ListAuditsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Audits) > 0 {
+		for _, e := range m.Audits {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	n += 1 + sovGenerated(uint64(m.AuditNumber))
+	return n
+}
+
 func (m *ListGroupNameRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2413,6 +2992,7 @@ func (m *LogStreamRequest) Size() (n int) {
 	n += 1 + l + sovGenerated(uint64(l))
 	l = len(m.Output)
 	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.Timestamp))
 	return n
 }
 
@@ -2533,7 +3113,7 @@ func (m *Result) Size() (n int) {
 	return n
 }
 
-func (m *RunStepRequest) Size() (n int) {
+func (m *RollbackRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -2545,27 +3125,56 @@ func (m *RunStepRequest) Size() (n int) {
 	n += 1 + l + sovGenerated(uint64(l))
 	l = len(m.RunnerName)
 	n += 1 + l + sovGenerated(uint64(l))
-	l = m.Step.Size()
-	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.RecordId))
 	return n
 }
 
-func (m *RunStepResponse) Size() (n int) {
+func (m *RollbackResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.Step.Size()
+	n += 1 + l + sovGenerated(uint64(l))
 	return n
 }
 
-func (m *RunnerInfo) Size() (n int) {
+func (m *RunStepRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Name)
+	l = len(m.Namespace)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.GroupName)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.RunnerName)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = m.Step.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
+func (m *RunStepResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RunnerInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
 	n += 1 + l + sovGenerated(uint64(l))
 	l = len(m.Hostname)
 	n += 1 + l + sovGenerated(uint64(l))
@@ -2727,6 +3336,42 @@ func sovGenerated(x uint64) (n int) {
 func sozGenerated(x uint64) (n int) {
 	return sovGenerated(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
+func (this *Audit) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChanges := "[]AuditChange{"
+	for _, f := range this.Changes {
+		repeatedStringForChanges += strings.Replace(strings.Replace(f.String(), "AuditChange", "AuditChange", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForChanges += "}"
+	s := strings.Join([]string{`&Audit{`,
+		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Actor:` + fmt.Sprintf("%v", this.Actor) + `,`,
+		`SourceIP:` + fmt.Sprintf("%v", this.SourceIP) + `,`,
+		`ServiceAPI:` + fmt.Sprintf("%v", this.ServiceAPI) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`GroupName:` + fmt.Sprintf("%v", this.GroupName) + `,`,
+		`RunnerName:` + fmt.Sprintf("%v", this.RunnerName) + `,`,
+		`StepName:` + fmt.Sprintf("%v", this.StepName) + `,`,
+		`Changes:` + repeatedStringForChanges + `,`,
+		`CreatedTM:` + fmt.Sprintf("%v", this.CreatedTM) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AuditChange) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AuditChange{`,
+		`Field:` + fmt.Sprintf("%v", this.Field) + `,`,
+		`Before:` + fmt.Sprintf("%v", this.Before) + `,`,
+		`After:` + fmt.Sprintf("%v", this.After) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *CompleteStepRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -2764,6 +3409,38 @@ func (this *Group) String() string {
 	}, "")
 	return s
 }
+func (this *ListAuditsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListAuditsRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`GroupName:` + fmt.Sprintf("%v", this.GroupName) + `,`,
+		`RunnerName:` + fmt.Sprintf("%v", this.RunnerName) + `,`,
+		`Actor:` + fmt.Sprintf("%v", this.Actor) + `,`,
+		`Page:` + fmt.Sprintf("%v", this.Page) + `,`,
+		`Length:` + fmt.Sprintf("%v", this.Length) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListAuditsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAudits := "[]Audit{"
+	for _, f := range this.Audits {
+		repeatedStringForAudits += strings.Replace(strings.Replace(f.String(), "Audit", "Audit", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForAudits += "}"
+	s := strings.Join([]string{`&ListAuditsResponse{`,
+		`Params:` + strings.Replace(strings.Replace(this.Params.String(), "ListAuditsRequest", "ListAuditsRequest", 1), `&`, ``, 1) + `,`,
+		`Audits:` + repeatedStringForAudits + `,`,
+		`AuditNumber:` + fmt.Sprintf("%v", this.AuditNumber) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *ListGroupNameRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -2871,6 +3548,7 @@ func (this *LogStreamRequest) String() string {
 		`RunnerName:` + fmt.Sprintf("%v", this.RunnerName) + `,`,
 		`StepName:` + fmt.Sprintf("%v", this.StepName) + `,`,
 		`Output:` + fmt.Sprintf("%v", this.Output) + `,`,
+		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2971,6 +3649,30 @@ func (this *Result) String() string {
 	}, "")
 	return s
 }
+func (this *RollbackRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RollbackRequest{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`GroupName:` + fmt.Sprintf("%v", this.GroupName) + `,`,
+		`RunnerName:` + fmt.Sprintf("%v", this.RunnerName) + `,`,
+		`RecordId:` + fmt.Sprintf("%v", this.RecordId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RollbackResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RollbackResponse{`,
+		`Params:` + strings.Replace(strings.Replace(this.Params.String(), "RollbackRequest", "RollbackRequest", 1), `&`, ``, 1) + `,`,
+		`Step:` + strings.Replace(strings.Replace(this.Step.String(), "Step", "Step", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *RunStepRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -3131,7 +3833,7 @@ func valueToStringGenerated(v interface{}) string {
 	pv := reflect.Indirect(rv).Interface()
 	return fmt.Sprintf("*%v", pv)
 }
-func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
+func (m *Audit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3154,15 +3856,34 @@ func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompleteStepRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Audit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompleteStepRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Audit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3190,11 +3911,11 @@ func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = Namespace(dAtA[iNdEx:postIndex])
+			m.Actor = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceIP", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3222,11 +3943,11 @@ func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GroupName = GroupName(dAtA[iNdEx:postIndex])
+			m.SourceIP = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RunnerName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAPI", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3254,13 +3975,13 @@ func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RunnerName = string(dAtA[iNdEx:postIndex])
+			m.ServiceAPI = ServiceAPI(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3270,136 +3991,29 @@ func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Step.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = Namespace(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CompleteStepResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenerated
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CompleteStepResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompleteStepResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenerated(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthGenerated
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Group) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenerated
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Group: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Group: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Runners", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -3409,26 +4023,970 @@ func (m *Group) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupName = GroupName(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunnerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RunnerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StepName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StepName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, AuditChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedTM", wireType)
+			}
+			m.CreatedTM = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedTM |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuditChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuditChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuditChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Before = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field After", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.After = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompleteStepRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompleteStepRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompleteStepRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = Namespace(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupName = GroupName(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunnerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RunnerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Step.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompleteStepResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompleteStepResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompleteStepResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Group) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Group: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Group: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runners", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Runners = append(m.Runners, RunnerInfo{})
+			if err := m.Runners[len(m.Runners)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAuditsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAuditsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAuditsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = Namespace(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupName = GroupName(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunnerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RunnerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Actor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+			}
+			m.Page = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Page |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAuditsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAuditsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAuditsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Runners = append(m.Runners, RunnerInfo{})
-			if err := m.Runners[len(m.Runners)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Audits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Audits = append(m.Audits, Audit{})
+			if err := m.Audits[len(m.Audits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuditNumber", wireType)
+			}
+			m.AuditNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AuditNumber |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -4499,6 +6057,25 @@ func (m *LogStreamRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Output = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -5027,16 +6604,136 @@ func (m *RegisterRunnerResponse) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RegisterRunnerResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegisterRunnerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisterRunnerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisterRunnerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Type.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -5061,7 +6758,7 @@ func (m *RegisterRunnerResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5084,13 +6781,64 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
@@ -5123,7 +6871,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
@@ -5181,7 +6929,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *Result) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5204,17 +6952,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: Result: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Result: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
 			}
-			m.Code = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -5224,14 +6972,80 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RollbackRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RollbackRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RollbackRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5259,13 +7073,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Namespace = Namespace(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupName", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -5275,30 +7089,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Type.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.GroupName = GroupName(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunnerName", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -5308,26 +7121,43 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.RunnerName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
+			}
+			m.RecordId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RecordId |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -5352,7 +7182,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Result) Unmarshal(dAtA []byte) error {
+func (m *RollbackResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5375,17 +7205,17 @@ func (m *Result) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Result: wiretype end group for non-group")
+			return fmt.Errorf("proto: RollbackResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Result: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RollbackResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenerated
@@ -5395,23 +7225,57 @@ func (m *Result) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenerated
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenerated
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, string(dAtA[iNdEx:postIndex]))
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Step.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -76,10 +76,18 @@ const (
 	RunStep                        ServiceAPI = "RunStep"
 	LogStream                      ServiceAPI = "LogStream"
 	CompleteStep                   ServiceAPI = "CompleteStep"
+	Shutdown                       ServiceAPI = "Shutdown"
 	ServiceAPIListRecordsRequest   ServiceAPI = "ListRecordsRequest"
 	ServiceAPIListRecordsResponse  ServiceAPI = "ListRecordsResponse"
 	ServiceAPIListVersionsRequest  ServiceAPI = "ListVersionRequest"
 	ServiceAPIListVersionsResponse ServiceAPI = "ListVersionResponse"
+	ServiceAPIListAuditsRequest    ServiceAPI = "ListAuditsRequest"
+	ServiceAPIListAuditsResponse   ServiceAPI = "ListAuditsResponse"
+	// ListRollbackVersionsRequest lists the successful version records which could be rolled back to
+	ServiceAPIListRollbackVersionsRequest  ServiceAPI = "ListRollbackVersionsRequest"
+	ServiceAPIListRollbackVersionsResponse ServiceAPI = "ListRollbackVersionsResponse"
+	ServiceAPIRollbackRequest              ServiceAPI = "RollbackRequest"
+	ServiceAPIRollbackResponse             ServiceAPI = "RollbackResponse"
 )
 
 type Result struct {
@@ -162,6 +170,8 @@ type LogStreamRequest struct {
 	RunnerName string    `json:"runnerName" protobuf:"bytes,3,opt,name=runnerName"`
 	StepName   string    `json:"stepName" protobuf:"bytes,4,opt,name=stepName"`
 	Output     string    `json:"output" protobuf:"bytes,5,opt,name=output"`
+	// Timestamp was the unix time in milliseconds when the Runner read the output
+	Timestamp int64 `json:"timestamp" protobuf:"varint,6,opt,name=timestamp"`
 }
 
 type LogStreamResponse struct {
@@ -185,3 +195,20 @@ type ListRecordsResponse struct {
 	Records      []Record           `json:"records" protobuf:"bytes,2,opt,name=records"`
 	RecordNumber int32              `json:"recordNumber" protobuf:"varint,3,opt,name=recordNumber"`
 }
+
+// RollbackRequest runs the recorded Step again as a rollback to the release which was published by the record
+type RollbackRequest struct {
+	Namespace Namespace `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	GroupName GroupName `json:"groupName" protobuf:"bytes,2,opt,name=groupName"`
+	// RunnerName was the Runner which would run the rollback, it was the recorded one by default
+	RunnerName string `json:"runnerName" protobuf:"bytes,3,opt,name=runnerName"`
+	// RecordId was the id of the successful version record
+	RecordId int32 `json:"recordId" protobuf:"varint,4,opt,name=recordId"`
+}
+
+// RollbackResponse
+type RollbackResponse struct {
+	Params RollbackRequest `json:"params" protobuf:"bytes,1,opt,name=params"`
+	// Step was the rollback Step which has been sent to the Runner
+	Step Step `json:"step" protobuf:"bytes,2,opt,name=step"`
+}
@@ -72,6 +72,7 @@ type Step struct {
 
 type UploadFile struct {
 	// SourceFile was the absolute path about the file which has been marked to be uploaded later.
+	// It could also be a directory or a glob pattern, whose files would be mirrored recursively under the TargetPath.
 	SourceFile string `json:"sourceFile" protobuf:"bytes,1,opt,name=sourceFile"`
 	// TargetPath was the target directory, it may be needed to be created before uploading the SourceFile
 	TargetPath string `json:"targetPath" protobuf:"bytes,2,opt,name=targetPath"`
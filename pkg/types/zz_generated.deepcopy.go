@@ -20,6 +20,43 @@ limitations under the License.
 
 package types
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Audit) DeepCopyInto(out *Audit) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]AuditChange, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Audit.
+func (in *Audit) DeepCopy() *Audit {
+	if in == nil {
+		return nil
+	}
+	out := new(Audit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditChange) DeepCopyInto(out *AuditChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditChange.
+func (in *AuditChange) DeepCopy() *AuditChange {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompleteStepRequest) DeepCopyInto(out *CompleteStepRequest) {
 	*out = *in
@@ -76,6 +113,46 @@ func (in *Group) DeepCopy() *Group {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListAuditsRequest) DeepCopyInto(out *ListAuditsRequest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListAuditsRequest.
+func (in *ListAuditsRequest) DeepCopy() *ListAuditsRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ListAuditsRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListAuditsResponse) DeepCopyInto(out *ListAuditsResponse) {
+	*out = *in
+	out.Params = in.Params
+	if in.Audits != nil {
+		in, out := &in.Audits, &out.Audits
+		*out = make([]Audit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListAuditsResponse.
+func (in *ListAuditsResponse) DeepCopy() *ListAuditsResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ListAuditsResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ListGroupNameRequest) DeepCopyInto(out *ListGroupNameRequest) {
 	*out = *in
@@ -412,6 +489,40 @@ func (in *Result) DeepCopy() *Result {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackRequest) DeepCopyInto(out *RollbackRequest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackRequest.
+func (in *RollbackRequest) DeepCopy() *RollbackRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackResponse) DeepCopyInto(out *RollbackResponse) {
+	*out = *in
+	out.Params = in.Params
+	in.Step.DeepCopyInto(&out.Step)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackResponse.
+func (in *RollbackResponse) DeepCopy() *RollbackResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunStepRequest) DeepCopyInto(out *RunStepRequest) {
 	*out = *in
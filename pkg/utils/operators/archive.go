@@ -0,0 +1,415 @@
+package operators
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// NewArchive returns the operator which packs the files of the source dir into the output archive,
+// the name should be unique in the Runner
+func NewArchive(name, sourceDir, output string) interfaces.StepOperator {
+	envs := make(map[string]string, 0)
+	envs[types.PublisherArchiveSourceDir] = sourceDir
+	envs[types.PublisherArchiveOutput] = output
+	return &archive{
+		step: &types.Step{
+			Id:             0,
+			Name:           name,
+			Phase:          types.StepPending,
+			Policy:         types.StepPolicyAuto,
+			Available:      types.StepAvailableEnable,
+			Envs:           envs,
+			Output:         make([]string, 0),
+			SharingData:    make(map[string]string, 0),
+			SharingSetting: false,
+		},
+	}
+}
+
+const (
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatTarGz = "tar.gz"
+
+	// ArchiveManifestSuffix was appended to the archive path as the manifest, which was in the `sha256sum` format
+	ArchiveManifestSuffix = ".sha256"
+
+	ErrArchiveSourceDirEmpty = "error: the archive_source_dir was empty"
+	ErrArchiveOutputEmpty    = "error: the archive_output was empty"
+	ErrArchiveFormatUnknown  = "error: unknown archive format:%s"
+	ErrArchiveNoMatches      = "error: no files in %s were matched by the archive_include:%q and the archive_exclude:%q"
+	archiveSummaryTemplate   = "archive: %s, %d files, %s, sha256:%s"
+)
+
+// archiveModTime was the modification time of all the entries, so that the same files produce the same archive
+var archiveModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// archive implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator
+type archive struct {
+	step *types.Step
+}
+
+// archiveEntry was a regular file which would be packed with its slash separated relative path
+type archiveEntry struct {
+	source string
+	name   string
+	mode   os.FileMode
+	size   int64
+	// linkname was the target of the symbolic link, it was empty for the regular file
+	linkname string
+}
+
+func (a *archive) Step() *types.Step {
+	return a.step
+}
+
+func (a *archive) Update(s *types.Step) {
+	a.step = s.DeepCopy()
+}
+
+func (a *archive) Prepare() {
+	a.step.Remarks = make([]string, 0)
+}
+
+func (a *archive) Run(output chan<- string) (res []string, err error) {
+	a.step.Phase = types.StepRunning
+	if a.step.SharingData == nil {
+		a.step.SharingData = make(map[string]string, 0)
+	}
+	delete(a.step.SharingData, types.PublisherArchiveFile)
+	delete(a.step.SharingData, types.PublisherArchiveSha256)
+	delete(a.step.SharingData, types.PublisherUploadFiles)
+	if err = a.run(output); err != nil {
+		klog.V(2).Info(err)
+		a.step.Phase = types.StepFailed
+		return res, err
+	}
+	a.step.Phase = types.StepSucceeded
+	return res, nil
+}
+
+func (a *archive) run(output chan<- string) error {
+	sourceDir := a.step.Envs[types.PublisherArchiveSourceDir]
+	if sourceDir == "" {
+		return errors.New(ErrArchiveSourceDirEmpty)
+	}
+	out := a.step.Envs[types.PublisherArchiveOutput]
+	if out == "" {
+		return errors.New(ErrArchiveOutputEmpty)
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(sourceDir, out)
+	}
+	out, err := filepath.Abs(out)
+	if err != nil {
+		return err
+	}
+	format, err := archiveFormat(a.step.Envs[types.PublisherArchiveFormat], out)
+	if err != nil {
+		return err
+	}
+	include := splitArchivePatterns(a.step.Envs[types.PublisherArchiveInclude])
+	exclude := splitArchivePatterns(a.step.Envs[types.PublisherArchiveExclude])
+	entries, err := collectArchiveEntries(sourceDir, include, exclude, out, out+ArchiveManifestSuffix)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf(ErrArchiveNoMatches, sourceDir, a.step.Envs[types.PublisherArchiveInclude], a.step.Envs[types.PublisherArchiveExclude])
+	}
+	output <- fmt.Sprintf("archive packing %d files into %s", len(entries), out)
+	sum, size, err := writeArchive(out, format, entries)
+	if err != nil {
+		return err
+	}
+	manifest := out + ArchiveManifestSuffix
+	if err = ioutil.WriteFile(manifest, []byte(fmt.Sprintf("%s  %s\n", sum, filepath.Base(out))), 0644); err != nil {
+		return err
+	}
+	targetPath := a.step.Envs[types.PublisherArchiveTargetPath]
+	uploads, err := json.Marshal([]types.UploadFile{
+		{SourceFile: out, TargetPath: targetPath, TargetFile: path.Join(targetPath, filepath.Base(out))},
+		{SourceFile: manifest, TargetPath: targetPath, TargetFile: path.Join(targetPath, filepath.Base(manifest))},
+	})
+	if err != nil {
+		return err
+	}
+	a.step.SharingData[types.PublisherArchiveFile] = out
+	a.step.SharingData[types.PublisherArchiveSha256] = sum
+	a.step.SharingData[types.PublisherUploadFiles] = string(uploads)
+	summary := fmt.Sprintf(archiveSummaryTemplate, filepath.Base(out), len(entries), formatBytes(float64(size)), sum)
+	a.step.Remarks = append(a.step.Remarks, summary)
+	output <- summary
+	return nil
+}
+
+// archiveFormat returns the format of the Envs, or detects it by the suffix of the output
+func archiveFormat(format, out string) (string, error) {
+	switch strings.ToLower(format) {
+	case ArchiveFormatZip:
+		return ArchiveFormatZip, nil
+	case ArchiveFormatTarGz, "tgz":
+		return ArchiveFormatTarGz, nil
+	case "":
+		lower := strings.ToLower(out)
+		if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+			return ArchiveFormatTarGz, nil
+		}
+		return ArchiveFormatZip, nil
+	}
+	return "", fmt.Errorf(ErrArchiveFormatUnknown, format)
+}
+
+// splitArchivePatterns splits the comma or newline separated patterns
+func splitArchivePatterns(s string) []string {
+	res := make([]string, 0)
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if v = strings.Trim(strings.TrimSpace(v), "/"); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// matchArchivePattern reports whether the slash separated relative name was matched by the pattern.
+// The `**` segment matches zero or more directories, and the other segments were matched by path.Match.
+// A pattern without the slash matches the name at any depth, such as `*.log`.
+func matchArchivePattern(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	return matchArchiveSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchArchiveSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchArchiveSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchArchivePatterns reports whether the name or one of its parent directories was matched by any of the patterns,
+// so that a pattern of the directory covers all the files under it
+func matchArchivePatterns(patterns []string, name string) bool {
+	for _, p := range patterns {
+		for n := name; n != "." && n != ""; n = path.Dir(n) {
+			if matchArchivePattern(p, n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectArchiveEntries returns the included regular files under the source dir sorted by their names,
+// the symbolic links and the skipped files were not packed
+func collectArchiveEntries(sourceDir string, include, exclude []string, skipped ...string) ([]archiveEntry, error) {
+	return walkArchiveEntries(sourceDir, include, exclude, false, skipped)
+}
+
+// walkArchiveEntries returns the included regular files under the source dir sorted by their names, and the symbolic
+// links were returned with their targets if the symlinks was true. The links would never be followed.
+func walkArchiveEntries(sourceDir string, include, exclude []string, symlinks bool, skipped []string) ([]archiveEntry, error) {
+	skip := make(map[string]struct{}, len(skipped))
+	for _, v := range skipped {
+		skip[v] = struct{}{}
+	}
+	res := make([]archiveEntry, 0)
+	err := filepath.Walk(sourceDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(sourceDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if info.IsDir() {
+			if matchArchivePatterns(exclude, name) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		linkname := ""
+		if info.Mode()&os.ModeSymlink != 0 && symlinks {
+			if linkname, err = os.Readlink(p); err != nil {
+				return err
+			}
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+		if abs, err := filepath.Abs(p); err == nil {
+			if _, ok := skip[abs]; ok {
+				return nil
+			}
+		}
+		if len(include) > 0 && !matchArchivePatterns(include, name) {
+			return nil
+		}
+		if matchArchivePatterns(exclude, name) {
+			return nil
+		}
+		if linkname != "" {
+			res = append(res, archiveEntry{source: p, name: name, mode: 0777, linkname: filepath.ToSlash(linkname)})
+			return nil
+		}
+		mode := os.FileMode(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		res = append(res, archiveEntry{source: p, name: name, mode: mode, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+	return res, nil
+}
+
+// writeArchive writes the entries into a temporary file beside the out and renames it,
+// and then it returns the hex sha256 and the size of the archive
+func writeArchive(out, format string, entries []archiveEntry) (sum string, size int64, err error) {
+	if err = os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return "", 0, err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(out), ".publisher-archive-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		if err != nil {
+			if e := os.Remove(f.Name()); e != nil {
+				klog.V(2).Info(e)
+			}
+		}
+	}()
+	h := sha256.New()
+	w := io.MultiWriter(f, h)
+	if format == ArchiveFormatTarGz {
+		err = writeTarGz(w, entries)
+	} else {
+		err = writeZip(w, entries)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	fi, err := os.Stat(f.Name())
+	if err != nil {
+		return "", 0, err
+	}
+	if err = os.Chmod(f.Name(), 0644); err != nil {
+		return "", 0, err
+	}
+	if err = os.Rename(f.Name(), out); err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), fi.Size(), nil
+}
+
+func writeZip(w io.Writer, entries []archiveEntry) error {
+	zw := zip.NewWriter(w)
+	for _, v := range entries {
+		header := &zip.FileHeader{
+			Name:     v.name,
+			Method:   zip.Deflate,
+			Modified: archiveModTime,
+		}
+		header.SetMode(v.mode)
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if err = copyArchiveEntry(fw, v); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeTarGz(w io.Writer, entries []archiveEntry) error {
+	// the gzip header was left without the name and the modification time
+	gw := gzip.NewWriter(w)
+	if err := writeTar(gw, entries); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeTar(w io.Writer, entries []archiveEntry) error {
+	tw := tar.NewWriter(w)
+	for _, v := range entries {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     v.name,
+			Size:     v.size,
+			Mode:     int64(v.mode),
+			ModTime:  archiveModTime,
+		}
+		if v.linkname != "" {
+			header.Typeflag = tar.TypeSymlink
+			header.Linkname = v.linkname
+			header.Size = 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if v.linkname != "" {
+			continue
+		}
+		if err := copyArchiveEntry(tw, v); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// copyArchiveEntry copies the content of the entry, the size was limited to the one of the header
+func copyArchiveEntry(w io.Writer, e archiveEntry) error {
+	f, err := os.Open(e.source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, io.LimitReader(f, e.size))
+	return err
+}
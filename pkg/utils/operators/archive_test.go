@@ -0,0 +1,231 @@
+package operators
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_matchArchivePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    bool
+	}{
+		{name: "Test_matchArchivePattern_1", pattern: "*.log", path: "a/b/c.log", want: true},
+		{name: "Test_matchArchivePattern_2", pattern: "bin/*", path: "bin/app", want: true},
+		{name: "Test_matchArchivePattern_3", pattern: "bin/*", path: "bin/sub/app", want: false},
+		{name: "Test_matchArchivePattern_4", pattern: "bin/**", path: "bin/sub/app", want: true},
+		{name: "Test_matchArchivePattern_5", pattern: "conf/**/*.json", path: "conf/a.json", want: true},
+		{name: "Test_matchArchivePattern_6", pattern: "conf/**/*.json", path: "conf/x/y/a.json", want: true},
+		{name: "Test_matchArchivePattern_7", pattern: "conf/**/*.json", path: "data/a.json", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchArchivePattern(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("matchArchivePattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newArchiveFixture(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"bin/app":          "#!/bin/sh\n",
+		"conf/app.json":    "{}",
+		"conf/dev/a.json":  "{\"dev\":true}",
+		"logs/run.log":     "log",
+		"README.md":        "readme",
+		"assets/a/run.log": "log",
+	}
+	for k, v := range files {
+		p := filepath.Join(dir, filepath.FromSlash(k))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(filepath.Join(dir, "bin", "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func readArchiveNames(t *testing.T, p, format string) []string {
+	res := make([]string, 0)
+	if format == ArchiveFormatZip {
+		r, err := zip.OpenReader(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		for _, v := range r.File {
+			res = append(res, v.Name+" "+v.Mode().String())
+		}
+		return res
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, h.Name+" "+h.FileInfo().Mode().String())
+	}
+	return res
+}
+
+func Test_archive_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		envs       map[string]string
+		wantFormat string
+		wantNames  []string
+		wantErr    bool
+	}{
+		{
+			name: "Test_archive_Run_1",
+			envs: map[string]string{
+				types.PublisherArchiveOutput:     "dist/build.zip",
+				types.PublisherArchiveExclude:    "*.log",
+				types.PublisherArchiveTargetPath: "releases",
+			},
+			wantFormat: ArchiveFormatZip,
+			wantNames:  []string{"README.md -rw-r--r--", "bin/app -rwxr-xr-x", "conf/app.json -rw-r--r--", "conf/dev/a.json -rw-r--r--"},
+		},
+		{
+			name: "Test_archive_Run_2",
+			envs: map[string]string{
+				types.PublisherArchiveOutput:  "dist/build.tgz",
+				types.PublisherArchiveInclude: "bin, conf/**/*.json",
+				types.PublisherArchiveExclude: "conf/dev",
+			},
+			wantFormat: ArchiveFormatTarGz,
+			wantNames:  []string{"bin/app -rwxr-xr-x", "conf/app.json -rw-r--r--"},
+		},
+		{
+			name: "Test_archive_Run_3",
+			envs: map[string]string{
+				types.PublisherArchiveOutput:  "dist/build.zip",
+				types.PublisherArchiveInclude: "*.exe",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test_archive_Run_4",
+			envs: map[string]string{
+				types.PublisherArchiveOutput: "dist/build.rar",
+				types.PublisherArchiveFormat: "rar",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newArchiveFixture(t)
+			a := NewArchive("Archive", dir, "").(*archive)
+			for k, v := range tt.envs {
+				a.step.Envs[k] = v
+			}
+			output := make(chan string, 100)
+			a.Prepare()
+			_, err := a.Run(output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if a.step.Phase != types.StepFailed {
+					t.Errorf("Run() phase = %v, want %v", a.step.Phase, types.StepFailed)
+				}
+				return
+			}
+			out := filepath.Join(dir, filepath.FromSlash(tt.envs[types.PublisherArchiveOutput]))
+			if got := a.step.SharingData[types.PublisherArchiveFile]; got != out {
+				t.Errorf("Run() archive = %v, want %v", got, out)
+			}
+			if got := readArchiveNames(t, out, tt.wantFormat); !reflect.DeepEqual(got, tt.wantNames) {
+				t.Errorf("Run() entries = %v, want %v", got, tt.wantNames)
+			}
+			manifest, err := ioutil.ReadFile(out + ArchiveManifestSuffix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum := a.step.SharingData[types.PublisherArchiveSha256]
+			if want := sum + "  " + filepath.Base(out) + "\n"; string(manifest) != want {
+				t.Errorf("Run() manifest = %q, want %q", manifest, want)
+			}
+			// the same files produce the same archive
+			if _, err = a.Run(output); err != nil {
+				t.Fatal(err)
+			}
+			if got := a.step.SharingData[types.PublisherArchiveSha256]; got != sum {
+				t.Errorf("Run() sha256 = %v, want %v", got, sum)
+			}
+			// the next Step uploads the archive and its manifest
+			next := &types.Step{SharingData: map[string]string{types.PublisherUploadFiles: a.step.SharingData[types.PublisherUploadFiles]}}
+			files, err := stepUploadFiles(next)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tasks, err := expandUploadFiles(files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetPath := tt.envs[types.PublisherArchiveTargetPath]
+			want := []uploadTask{
+				{source: out, target: filepath.ToSlash(filepath.Join(targetPath, filepath.Base(out)))},
+				{source: out + ArchiveManifestSuffix, target: filepath.ToSlash(filepath.Join(targetPath, filepath.Base(out)+ArchiveManifestSuffix))},
+			}
+			for i := range tasks {
+				tasks[i].size = 0
+			}
+			if !reflect.DeepEqual(tasks, want) {
+				t.Errorf("expandUploadFiles() = %v, want %v", tasks, want)
+			}
+		})
+	}
+}
+
+func Test_stepUploadFiles(t *testing.T) {
+	own := []types.UploadFile{{SourceFile: "/data/a.txt", TargetFile: "a.txt"}}
+	shared := []types.UploadFile{{SourceFile: "/data/b.zip", TargetFile: "b.zip"}}
+	data, err := json.Marshal(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := stepUploadFiles(&types.Step{UploadFiles: own, SharingData: map[string]string{types.PublisherUploadFiles: string(data)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append(append([]types.UploadFile{}, own...), shared...); !reflect.DeepEqual(got, want) {
+		t.Errorf("stepUploadFiles() = %v, want %v", got, want)
+	}
+	if _, err = stepUploadFiles(&types.Step{SharingData: map[string]string{types.PublisherUploadFiles: "{"}}); err == nil {
+		t.Errorf("stepUploadFiles() error = nil, want the invalid json error")
+	}
+}
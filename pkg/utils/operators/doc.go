@@ -1,3 +1,3 @@
-// Package operators contains a series of steps such as Ftp, Git, Svn, Robot.
+// Package operators contains a series of steps such as Ftp, Git, Svn, Script, Archive, Http, Docker, Kube, Robot.
 // And they were all implementing the github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator
 package operators
@@ -0,0 +1,466 @@
+package operators
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// NewDocker returns the operator which builds the image from the context dir and pushes it through the Docker Engine API,
+// the name should be unique in the Runner
+func NewDocker(name, contextDir, image string) interfaces.StepOperator {
+	envs := make(map[string]string, 0)
+	envs[types.PublisherDockerContextDir] = contextDir
+	envs[types.PublisherDockerImage] = image
+	return &docker{
+		step: &types.Step{
+			Id:             0,
+			Name:           name,
+			Phase:          types.StepPending,
+			Policy:         types.StepPolicyAuto,
+			Available:      types.StepAvailableEnable,
+			Envs:           envs,
+			Output:         make([]string, 0),
+			SharingData:    make(map[string]string, 0),
+			SharingSetting: false,
+		},
+	}
+}
+
+const (
+	DockerDefaultHost       = "unix:///var/run/docker.sock"
+	DockerDefaultDockerfile = "Dockerfile"
+	DockerDefaultTags       = "{{.Version}},{{.GitShortSha}}"
+	DockerLatestTag         = "latest"
+
+	ErrDockerContextDirEmpty = "error: the docker_context_dir was empty"
+	ErrDockerImageEmpty      = "error: the docker_image was empty"
+	ErrDockerHostInvalid     = "error: the docker_host:%s was invalid, it should be unix:///path or tcp://host:port"
+	ErrDockerTagInvalid      = "error: the docker tag:%q was invalid"
+	ErrDockerBuildArgInvalid = "error: the docker build arg:%q should be `KEY=VALUE`"
+	ErrDockerApi             = "error: docker %s responded %d: %s"
+	ErrDockerMessage         = "error: docker %s failed: %s"
+	ErrDockerCancelled       = "error: docker %s was cancelled"
+	dockerIgnoreFile         = ".dockerignore"
+	// dockerApiHost was the placeholder host of the requests, the connections were dialed to the docker_host
+	dockerApiHost = "docker"
+)
+
+// docker implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator and Canceler
+type docker struct {
+	step *types.Step
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// dockerMessage was a line of the json stream which was responded by the build and the push
+type dockerMessage struct {
+	Stream   string          `json:"stream"`
+	Status   string          `json:"status"`
+	ID       string          `json:"id"`
+	Progress string          `json:"progress"`
+	Error    string          `json:"error"`
+	Aux      json.RawMessage `json:"aux"`
+}
+
+func (d *docker) Step() *types.Step {
+	return d.step
+}
+
+func (d *docker) Update(s *types.Step) {
+	d.step = s.DeepCopy()
+}
+
+func (d *docker) Prepare() {
+	d.step.Remarks = make([]string, 0)
+}
+
+// Cancel aborts the building or the pushing, it does nothing if the docker was not running
+func (d *docker) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		d.cancel()
+	}
+}
+
+func (d *docker) Run(output chan<- string) (res []string, err error) {
+	d.step.Phase = types.StepRunning
+	if d.step.SharingData == nil {
+		d.step.SharingData = make(map[string]string, 0)
+	}
+	delete(d.step.SharingData, types.PublisherDockerImageName)
+	delete(d.step.SharingData, types.PublisherDockerImageId)
+	delete(d.step.SharingData, types.PublisherDockerImageDigest)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d.mu.Lock()
+	d.cancel = cancel
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		d.cancel = nil
+		d.mu.Unlock()
+	}()
+	if err = d.run(ctx, output); err != nil {
+		klog.V(2).Info(err)
+		d.step.Phase = types.StepFailed
+		return res, err
+	}
+	d.step.Phase = types.StepSucceeded
+	return res, nil
+}
+
+func (d *docker) run(ctx context.Context, output chan<- string) error {
+	contextDir := d.step.Envs[types.PublisherDockerContextDir]
+	if contextDir == "" {
+		return errors.New(ErrDockerContextDirEmpty)
+	}
+	image := strings.TrimSpace(d.step.Envs[types.PublisherDockerImage])
+	if image == "" {
+		return errors.New(ErrDockerImageEmpty)
+	}
+	client, err := dockerClient(d.step.Envs[types.PublisherDockerHost])
+	if err != nil {
+		return err
+	}
+	defer client.CloseIdleConnections()
+	tags, err := d.tags()
+	if err != nil {
+		return err
+	}
+	buildArgs, err := d.buildArgs()
+	if err != nil {
+		return err
+	}
+	id, err := d.build(ctx, client, contextDir, image, tags, buildArgs, output)
+	if err != nil {
+		return err
+	}
+	d.step.SharingData[types.PublisherDockerImageName] = image + ":" + tags[0]
+	if id != "" {
+		d.step.SharingData[types.PublisherDockerImageId] = id
+	}
+	d.step.Remarks = append(d.step.Remarks, fmt.Sprintf("docker built %s:%s %s", image, strings.Join(tags, ","), id))
+	if v := d.step.Envs[types.PublisherDockerPush]; v != "" && !envEnabled(d.step.Envs, types.PublisherDockerPush) {
+		return nil
+	}
+	for i, tag := range tags {
+		digest, err := d.push(ctx, client, image, tag, output)
+		if err != nil {
+			return err
+		}
+		if i == 0 && digest != "" {
+			d.step.SharingData[types.PublisherDockerImageDigest] = digest
+		}
+		d.step.Remarks = append(d.step.Remarks, fmt.Sprintf("docker pushed %s:%s %s", image, tag, digest))
+	}
+	return nil
+}
+
+// dockerClient returns the client whose connections were dialed to the unix socket or the tcp address of the host
+func dockerClient(host string) (*http.Client, error) {
+	if host == "" {
+		host = DockerDefaultHost
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf(ErrDockerHostInvalid, host)
+	}
+	network, address := "", ""
+	switch u.Scheme {
+	case "unix":
+		network, address = "unix", u.Path
+	case "tcp":
+		network, address = "tcp", u.Host
+	}
+	if network == "" || address == "" {
+		return nil, fmt.Errorf(ErrDockerHostInvalid, host)
+	}
+	dialer := &net.Dialer{}
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+		},
+	}, nil
+}
+
+// tags renders the docker_tags, the duplicated and the empty ones were skipped
+func (d *docker) tags() ([]string, error) {
+	text := d.step.Envs[types.PublisherDockerTags]
+	if text == "" {
+		text = DockerDefaultTags
+	}
+	data := newStepTemplateData(d.step)
+	res := make([]string, 0)
+	seen := make(map[string]struct{}, 0)
+	for _, v := range strings.Split(text, ",") {
+		tag, err := renderStepTemplate(v, data)
+		if err != nil {
+			return nil, err
+		}
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		if !validDockerTag(tag) {
+			return nil, fmt.Errorf(ErrDockerTagInvalid, tag)
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	if len(res) == 0 {
+		res = append(res, DockerLatestTag)
+	}
+	return res, nil
+}
+
+// validDockerTag reports whether the tag matched [A-Za-z0-9_][A-Za-z0-9_.-]{0,127}
+func validDockerTag(tag string) bool {
+	if len(tag) > 128 || tag[0] == '.' || tag[0] == '-' {
+		return false
+	}
+	for _, r := range tag {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// buildArgs renders the values of the docker_build_args
+func (d *docker) buildArgs() (map[string]string, error) {
+	res := make(map[string]string, 0)
+	data := newStepTemplateData(d.step)
+	for _, line := range strings.Split(d.step.Envs[types.PublisherDockerBuildArgs], "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, fmt.Errorf(ErrDockerBuildArgInvalid, line)
+		}
+		value, err := renderStepTemplate(strings.TrimSpace(kv[1]), data)
+		if err != nil {
+			return nil, err
+		}
+		res[key] = value
+	}
+	return res, nil
+}
+
+// build sends the context as a tar stream to the /build, and returns the id of the built image
+func (d *docker) build(ctx context.Context, client *http.Client, contextDir, image string, tags []string,
+	buildArgs map[string]string, output chan<- string) (string, error) {
+	dockerfile := d.step.Envs[types.PublisherDockerfile]
+	if dockerfile == "" {
+		dockerfile = DockerDefaultDockerfile
+	}
+	entries, err := dockerContextEntries(contextDir, filepath.ToSlash(path.Clean(dockerfile)))
+	if err != nil {
+		return "", err
+	}
+	args, err := json.Marshal(buildArgs)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	for _, tag := range tags {
+		query.Add("t", image+":"+tag)
+	}
+	query.Set("dockerfile", dockerfile)
+	query.Set("buildargs", string(args))
+	query.Set("rm", "1")
+	if envEnabled(d.step.Envs, types.PublisherDockerNoCache) {
+		query.Set("nocache", "1")
+	}
+	output <- fmt.Sprintf("docker building %s with %d files of %s", image, len(entries), contextDir)
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeTar(writer, entries))
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+dockerApiHost+"/build?"+query.Encode(), reader)
+	if err != nil {
+		reader.Close()
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+	var id string
+	err = d.stream(ctx, client, req, "build", output, func(aux json.RawMessage) {
+		v := struct {
+			ID string `json:"ID"`
+		}{}
+		if json.Unmarshal(aux, &v) == nil && v.ID != "" {
+			id = v.ID
+		}
+	})
+	reader.Close()
+	return id, err
+}
+
+// push pushes the tag of the image to the registry, and returns the digest of the pushed manifest.
+// The X-Registry-Auth was only sent with the registry credentials, otherwise the empty legacy auth body was sent
+// which the daemon accepts for the anonymous pushes.
+func (d *docker) push(ctx context.Context, client *http.Client, image, tag string, output chan<- string) (string, error) {
+	segments := strings.Split(image, "/")
+	for i, v := range segments {
+		segments[i] = url.PathEscape(v)
+	}
+	output <- fmt.Sprintf("docker pushing %s:%s", image, tag)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"http://"+dockerApiHost+"/images/"+strings.Join(segments, "/")+"/push?"+url.Values{"tag": {tag}}.Encode(), strings.NewReader("{}"))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	username := d.step.Envs[types.PublisherDockerRegistryUsername]
+	password := d.step.Envs[types.PublisherDockerRegistryPassword]
+	if username != "" || password != "" {
+		auth, err := json.Marshal(map[string]string{
+			"username":      username,
+			"password":      password,
+			"serveraddress": d.step.Envs[types.PublisherDockerRegistryServer],
+		})
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("X-Registry-Auth", base64.URLEncoding.EncodeToString(auth))
+	}
+	var digest string
+	err = d.stream(ctx, client, req, "push", output, func(aux json.RawMessage) {
+		v := struct {
+			Digest string `json:"Digest"`
+		}{}
+		if json.Unmarshal(aux, &v) == nil && v.Digest != "" {
+			digest = v.Digest
+		}
+	})
+	return digest, err
+}
+
+// stream sends the request and streams the json messages of the response into the output.
+// The progress bars were skipped, and the aux messages were handled by the fc.
+func (d *docker) stream(ctx context.Context, client *http.Client, req *http.Request, action string,
+	output chan<- string, fc func(aux json.RawMessage)) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf(ErrDockerCancelled, action)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, httpMaxOutputBytes))
+		message := strings.TrimSpace(string(body))
+		v := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(body, &v) == nil && v.Message != "" {
+			message = v.Message
+		}
+		return fmt.Errorf(ErrDockerApi, action, resp.StatusCode, message)
+	}
+	decoder := json.NewDecoder(bufio.NewReader(resp.Body))
+	for {
+		var m dockerMessage
+		if err = decoder.Decode(&m); err == io.EOF {
+			return nil
+		} else if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf(ErrDockerCancelled, action)
+			}
+			return err
+		}
+		if m.Error != "" {
+			return fmt.Errorf(ErrDockerMessage, action, m.Error)
+		}
+		if len(m.Aux) > 0 {
+			fc(m.Aux)
+		}
+		for _, line := range strings.Split(m.Stream, "\n") {
+			if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+				output <- line
+			}
+		}
+		if m.Status != "" && m.Progress == "" {
+			if m.ID != "" {
+				output <- m.ID + ": " + m.Status
+			} else {
+				output <- m.Status
+			}
+		}
+	}
+}
+
+// dockerContextEntries returns the files of the context which were not ignored by the .dockerignore,
+// the Dockerfile was always sent. The symbolic links were sent as they were, as the docker cli does.
+func dockerContextEntries(contextDir, dockerfile string) ([]archiveEntry, error) {
+	if _, err := os.Stat(contextDir); err != nil {
+		return nil, err
+	}
+	entries, err := walkArchiveEntries(contextDir, nil, nil, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	patterns := make([]string, 0)
+	data, err := ioutil.ReadFile(filepath.Join(contextDir, dockerIgnoreFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	res := make([]archiveEntry, 0, len(entries))
+	for _, v := range entries {
+		if v.name == dockerfile || !dockerIgnored(patterns, v.name) {
+			res = append(res, v)
+		}
+	}
+	return res, nil
+}
+
+// dockerIgnored reports whether the name was ignored by the patterns of the .dockerignore.
+// The patterns were relative to the context, the later `!` patterns re-include the names,
+// and a pattern of the directory covers all the files under it.
+func dockerIgnored(patterns []string, name string) bool {
+	ignored := false
+	for _, p := range patterns {
+		negated := strings.HasPrefix(p, "!")
+		p = strings.Trim(path.Clean("/"+strings.TrimPrefix(p, "!")), "/")
+		segments := strings.Split(p, "/")
+		for n := name; n != "." && n != ""; n = path.Dir(n) {
+			if matchArchiveSegments(segments, strings.Split(n, "/")) {
+				ignored = !negated
+				break
+			}
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,301 @@
+package operators
+
+import (
+	"archive/tar"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+// fakeDocker was a fake Docker Engine API which listened on a unix socket
+type fakeDocker struct {
+	mu        sync.Mutex
+	host      string
+	files     []string
+	query     url.Values
+	pushes    []string
+	auth      map[string]string
+	buildFail bool
+}
+
+func newFakeDocker(t *testing.T) *fakeDocker {
+	// the unix socket path should be short
+	dir, err := ioutil.TempDir("", "docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(dir, "docker.sock")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeDocker{host: "unix://" + socket}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/build", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.query = r.URL.Query()
+		f.files = make([]string, 0)
+		tr := tar.NewReader(r.Body)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, `{"message":"invalid tar"}`, http.StatusBadRequest)
+				return
+			}
+			if h.Typeflag == tar.TypeSymlink {
+				f.files = append(f.files, h.Name+" -> "+h.Linkname)
+				continue
+			}
+			f.files = append(f.files, h.Name)
+		}
+		if f.buildFail {
+			_, _ = fmt.Fprintln(w, `{"stream":"Step 1/2 : FROM alpine\n"}`)
+			_, _ = fmt.Fprintln(w, `{"error":"The command '/bin/sh -c false' returned a non-zero code: 1"}`)
+			return
+		}
+		_, _ = fmt.Fprintln(w, `{"stream":"Step 1/2 : FROM alpine\n"}`)
+		_, _ = fmt.Fprintln(w, `{"stream":" ---> a24bb4013296\nStep 2/2 : COPY . /app\n"}`)
+		_, _ = fmt.Fprintln(w, `{"aux":{"ID":"sha256:1234"}}`)
+		_, _ = fmt.Fprintln(w, `{"stream":"Successfully built 1234\n"}`)
+	})
+	mux.HandleFunc("/images/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.EscapedPath(), "/images/"), "/push")
+		tag := r.URL.Query().Get("tag")
+		f.auth = nil
+		if header := r.Header.Get("X-Registry-Auth"); header != "" {
+			data, err := base64.URLEncoding.DecodeString(header)
+			if err == nil {
+				err = json.Unmarshal(data, &f.auth)
+			}
+			if err != nil {
+				http.Error(w, `{"message":"invalid auth"}`, http.StatusBadRequest)
+				return
+			}
+		}
+		f.pushes = append(f.pushes, name+":"+tag)
+		_, _ = fmt.Fprintf(w, `{"status":"The push refers to repository [%s]"}`+"\n", name)
+		_, _ = fmt.Fprintln(w, `{"status":"Pushing","progressDetail":{"current":1,"total":2},"progress":"[==>  ]","id":"5f70bf18a086"}`)
+		_, _ = fmt.Fprintln(w, `{"status":"Pushed","progressDetail":{},"id":"5f70bf18a086"}`)
+		_, _ = fmt.Fprintf(w, `{"aux":{"Tag":"%s","Digest":"sha256:abcd-%s","Size":528}}`+"\n", tag, tag)
+	})
+	server := &http.Server{Handler: mux}
+	go func() {
+		_ = server.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = os.RemoveAll(dir)
+	})
+	return f
+}
+
+func newDockerContext(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":     "FROM alpine\nCOPY . /app\n",
+		".dockerignore":  "# comment\nlogs\n*.tmp\n!keep.tmp\nDockerfile\n",
+		"app/main":       "binary",
+		"app/conf.json":  "{}",
+		"logs/run.log":   "log",
+		"cache.tmp":      "tmp",
+		"keep.tmp":       "keep",
+		"app/nested.tmp": "nested",
+	}
+	for k, v := range files {
+		p := filepath.Join(dir, filepath.FromSlash(k))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for k, v := range map[string]string{"app/current": "main", "logs/latest.log": "run.log"} {
+		if err := os.Symlink(v, filepath.Join(dir, filepath.FromSlash(k))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_dockerIgnored(t *testing.T) {
+	patterns := []string{"logs", "*.tmp", "!keep.tmp", "**/*.bak", "/build/"}
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "Test_dockerIgnored_1", path: "logs/a/run.log", want: true},
+		{name: "Test_dockerIgnored_2", path: "cache.tmp", want: true},
+		{name: "Test_dockerIgnored_3", path: "keep.tmp", want: false},
+		{name: "Test_dockerIgnored_4", path: "app/nested.tmp", want: false},
+		{name: "Test_dockerIgnored_5", path: "app/a/b.bak", want: true},
+		{name: "Test_dockerIgnored_6", path: "build/out", want: true},
+		{name: "Test_dockerIgnored_7", path: "app/logs", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dockerIgnored(patterns, tt.path); got != tt.want {
+				t.Errorf("dockerIgnored() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_docker_Run(t *testing.T) {
+	tests := []struct {
+		name            string
+		image           string
+		envs            map[string]string
+		sharingData     map[string]string
+		buildFail       bool
+		wantTags        []string
+		wantBuildArgs   map[string]string
+		wantPushes      []string
+		wantAuth        string
+		wantSharingData map[string]string
+		wantErr         bool
+	}{
+		{
+			name: "Test_docker_Run_1",
+			envs: map[string]string{
+				types.PublisherDockerBuildArgs:        "VERSION={{.Version}}\nCOMMIT={{.GitSha}}",
+				types.PublisherDockerRegistryServer:   "registry.example.com",
+				types.PublisherDockerRegistryUsername: "robot",
+				types.PublisherDockerRegistryPassword: "secret",
+			},
+			sharingData:   map[string]string{types.PublisherVersion: "1.0.0", types.PublisherGitSha: "9e8e0b3aa", types.PublisherGitShortSha: "9e8e0b3"},
+			wantTags:      []string{"registry.example.com/game/server:1.0.0", "registry.example.com/game/server:9e8e0b3"},
+			wantBuildArgs: map[string]string{"VERSION": "1.0.0", "COMMIT": "9e8e0b3aa"},
+			wantPushes:    []string{"registry.example.com/game/server:1.0.0", "registry.example.com/game/server:9e8e0b3"},
+			wantAuth:      "robot",
+			wantSharingData: map[string]string{
+				types.PublisherVersion:           "1.0.0",
+				types.PublisherGitSha:            "9e8e0b3aa",
+				types.PublisherGitShortSha:       "9e8e0b3",
+				types.PublisherDockerImageName:   "registry.example.com/game/server:1.0.0",
+				types.PublisherDockerImageId:     "sha256:1234",
+				types.PublisherDockerImageDigest: "sha256:abcd-1.0.0",
+			},
+		},
+		{
+			name:          "Test_docker_Run_2",
+			envs:          map[string]string{types.PublisherDockerPush: "false", types.PublisherDockerNoCache: "true"},
+			wantTags:      []string{"registry.example.com/game/server:latest"},
+			wantBuildArgs: map[string]string{},
+			wantSharingData: map[string]string{
+				types.PublisherDockerImageName: "registry.example.com/game/server:latest",
+				types.PublisherDockerImageId:   "sha256:1234",
+			},
+		},
+		{
+			name:            "Test_docker_Run_3",
+			buildFail:       true,
+			wantTags:        []string{"registry.example.com/game/server:latest"},
+			wantBuildArgs:   map[string]string{},
+			wantSharingData: map[string]string{},
+			wantErr:         true,
+		},
+		{
+			name:            "Test_docker_Run_4",
+			envs:            map[string]string{types.PublisherDockerTags: "v1/bad"},
+			wantSharingData: map[string]string{},
+			wantErr:         true,
+		},
+		{
+			name:          "Test_docker_Run_5",
+			image:         "registry.example.com/game/server?x#y",
+			wantTags:      []string{"registry.example.com/game/server?x#y:latest"},
+			wantBuildArgs: map[string]string{},
+			wantPushes:    []string{"registry.example.com/game/server%3Fx%23y:latest"},
+			wantSharingData: map[string]string{
+				types.PublisherDockerImageName:   "registry.example.com/game/server?x#y:latest",
+				types.PublisherDockerImageId:     "sha256:1234",
+				types.PublisherDockerImageDigest: "sha256:abcd-latest",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeDocker(t)
+			server.buildFail = tt.buildFail
+			image := tt.image
+			if image == "" {
+				image = "registry.example.com/game/server"
+			}
+			d := NewDocker("Docker", newDockerContext(t), image).(*docker)
+			d.step.Envs[types.PublisherDockerHost] = server.host
+			for k, v := range tt.envs {
+				d.step.Envs[k] = v
+			}
+			for k, v := range tt.sharingData {
+				d.step.SharingData[k] = v
+			}
+			output := make(chan string, 100)
+			d.Prepare()
+			_, err := d.Run(output)
+			close(output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(d.step.SharingData, tt.wantSharingData) {
+				t.Errorf("Run() SharingData = %v, want %v", d.step.SharingData, tt.wantSharingData)
+			}
+			if tt.wantTags == nil {
+				return
+			}
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			sort.Strings(server.files)
+			if want := []string{".dockerignore", "Dockerfile", "app/conf.json", "app/current -> main", "app/main", "app/nested.tmp", "keep.tmp"}; !reflect.DeepEqual(server.files, want) {
+				t.Errorf("Run() context = %v, want %v", server.files, want)
+			}
+			if !reflect.DeepEqual(server.query["t"], tt.wantTags) {
+				t.Errorf("Run() tags = %v, want %v", server.query["t"], tt.wantTags)
+			}
+			buildArgs := make(map[string]string)
+			if err = json.Unmarshal([]byte(server.query.Get("buildargs")), &buildArgs); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(buildArgs, tt.wantBuildArgs) {
+				t.Errorf("Run() buildargs = %v, want %v", buildArgs, tt.wantBuildArgs)
+			}
+			if got := server.query.Get("nocache") == "1"; got != (tt.envs[types.PublisherDockerNoCache] == "true") {
+				t.Errorf("Run() nocache = %v", got)
+			}
+			if !reflect.DeepEqual(server.pushes, tt.wantPushes) {
+				t.Errorf("Run() pushes = %v, want %v", server.pushes, tt.wantPushes)
+			}
+			if tt.wantPushes != nil && server.auth["username"] != tt.wantAuth {
+				t.Errorf("Run() auth = %v, want the username %v", server.auth, tt.wantAuth)
+			}
+			lines := make([]string, 0)
+			for v := range output {
+				lines = append(lines, v)
+			}
+			if !strings.Contains(strings.Join(lines, "\n"), "Step 2/2 : COPY . /app") && !tt.buildFail {
+				t.Errorf("Run() output = %v, want the build stream", lines)
+			}
+		})
+	}
+}
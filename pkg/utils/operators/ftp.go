@@ -1,12 +1,22 @@
 package operators
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Shanghai-Lunara/go-gpt/pkg/operator"
 	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	goftp "github.com/jlaffaye/ftp"
 	"k8s.io/klog/v2"
 )
 
@@ -35,6 +45,16 @@ func NewFtp(host string, port int, username, password, workDir string, timeout i
 
 const (
 	FtpMkdirMark = "mark"
+
+	FtpVerifySize     = "size"
+	FtpVerifyChecksum = "checksum"
+	FtpVerifyNone     = "none"
+
+	ErrFtpSizeMismatch     = "error: the uploaded file:%s size:%d was not matched the local size:%d"
+	ErrFtpChecksumMismatch = "error: the uploaded file:%s md5:%s was not matched the local md5:%s"
+	ErrFtpVerifyUnknown    = "error: unknown ftp verification:%s"
+	ErrFtpSyncWithRelease  = "error: the incremental ftp_sync could not be combined with the release directories of the PUBLISHER_FTP_MKDIR"
+	ftpDefaultWorkers      = 4
 )
 
 // ftp implements github.com/nevercase/publisher/pkg/interfaces.StepOperator
@@ -58,7 +78,10 @@ func (f *ftp) SettingPrepareFunc(fc func()) {
 }
 
 func (f *ftp) Prepare() {
-	f.prepareFunc()
+	f.step.Remarks = make([]string, 0)
+	if f.prepareFunc != nil {
+		f.prepareFunc()
+	}
 }
 
 func (f *ftp) Run(output chan<- string) (res []string, err error) {
@@ -68,39 +91,108 @@ func (f *ftp) Run(output chan<- string) (res []string, err error) {
 		f.step.Phase = types.StepFailed
 		return nil, err
 	}
+	if f.step.SharingData == nil {
+		f.step.SharingData = make(map[string]string, 0)
+	}
+	delete(f.step.SharingData, types.PublisherRollbackRecordId)
+	if _, ok := f.step.Envs[types.PublisherRollbackRecordId]; ok {
+		if err = f.rollback(output); err != nil {
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
+		}
+		f.step.Phase = types.StepSucceeded
+		return res, nil
+	}
 	prefix := ""
-	if mark, ok := f.step.Envs[types.PublisherFtpMkdir]; ok {
-		if mark == FtpMkdirMark {
-			dir, err := f.yunLuoMkdir()
-			if err != nil {
-				klog.V(2).Info(err)
-				f.step.Phase = types.StepFailed
-				return res, err
-			}
-			f.step.Envs[types.PublisherFtpMkdir] = dir
-			prefix = dir
-			c, err := f.operator.Conn()
-			if err != nil {
-				klog.V(2).Info(err)
-				f.step.Phase = types.StepFailed
-				return res, err
-			}
-			if err := c.MakeDir(fmt.Sprintf("%s/%s", f.config.WorkDir, dir)); err != nil {
-				klog.V(2).Info(err)
-				f.step.Phase = types.StepFailed
-				return res, err
-			}
+	if mark, ok := f.step.Envs[types.PublisherFtpMkdir]; ok && mark == FtpMkdirMark {
+		// each release directory was new and FTP could not copy the unchanged files on the server,
+		// so that the incremental sync would upload everything while it looked like saving the bandwidth
+		if f.step.Envs[types.PublisherFtpSync] == FtpSyncIncremental {
+			err = errors.New(ErrFtpSyncWithRelease)
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
 		}
+		if prefix, err = f.mkdirRelease(); err != nil {
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
+		}
+		publishReleaseDir(f.step, prefix)
+		output <- fmt.Sprintf("ftp created the release directory %s", prefix)
 	}
-	for _, v := range f.step.UploadFiles {
-		target := v.TargetFile
-		if prefix != "" {
-			target = fmt.Sprintf("%s/%s", prefix, target)
+	files, err := stepUploadFiles(f.step)
+	if err != nil {
+		klog.V(2).Info(err)
+		f.step.Phase = types.StepFailed
+		return res, err
+	}
+	tasks, err := expandUploadFiles(files)
+	if err != nil {
+		klog.V(2).Info(err)
+		f.step.Phase = types.StepFailed
+		return res, err
+	}
+	writes := make([]uploadTask, 0)
+	if len(f.step.WriteFiles) > 0 {
+		dir, err := ioutil.TempDir("", "publisher-write-files")
+		if err != nil {
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
 		}
-		if err := f.operator.UploadFile(v.SourceFile, target); err != nil {
+		defer os.RemoveAll(dir)
+		if writes, err = writeFileTasks(dir, f.step.WriteFiles); err != nil {
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
+		}
+	}
+	var plan *ftpSyncPlan
+	if f.step.Envs[types.PublisherFtpSync] == FtpSyncIncremental {
+		all := make([]uploadTask, 0, len(tasks)+len(writes))
+		if plan, err = f.planSync(prefix, append(append(all, tasks...), writes...)); err != nil {
 			klog.V(2).Info(err)
 			f.step.Phase = types.StepFailed
-			return res, nil
+			return res, err
+		}
+		tasks, writes = splitUploadTasks(plan.uploads, writes)
+	}
+	start := time.Now()
+	root := path.Join(f.config.WorkDir, prefix)
+	n, err := f.uploadAll(root, tasks, output)
+	if err != nil {
+		klog.V(2).Info(err)
+		f.step.Phase = types.StepFailed
+		return res, err
+	}
+	// the WriteFiles were uploaded after the others, such as the version file which announces them
+	m, err := f.uploadAll(root, writes, output)
+	if err != nil {
+		klog.V(2).Info(err)
+		f.step.Phase = types.StepFailed
+		return res, err
+	}
+	n += m
+	if plan != nil {
+		if err = f.finishSync(prefix, plan, output); err != nil {
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
+		}
+	}
+	f.step.Remarks = append(f.step.Remarks, uploadSummary(len(tasks)+len(writes), n, time.Since(start)))
+	if prefix != "" {
+		if err = f.updateLatest(prefix); err != nil {
+			klog.V(2).Info(err)
+			f.step.Phase = types.StepFailed
+			return res, err
+		}
+		// the release has been published, so the failure of the retention would not fail the Step
+		if err = f.prune(prefix, output); err != nil {
+			klog.Warning(err)
+			output <- fmt.Sprintf("ftp retention err:%v", err)
 		}
 	}
 	f.step.Phase = types.StepSucceeded
@@ -130,15 +222,174 @@ func (f *ftp) ReloadConfig() (err error) {
 	return nil
 }
 
-func (f *ftp) yunLuoMkdir() (dir string, err error) {
-	date := time.Now().Format("20060102")
-	res, err := f.operator.List(date)
+// uploadAll uploads the tasks under the root through the parallel connections,
+// and it returns the total uploaded bytes or the first error
+func (f *ftp) uploadAll(root string, tasks []uploadTask, output chan<- string) (n int64, err error) {
+	if len(tasks) == 0 {
+		return 0, nil
+	}
+	verify := f.step.Envs[types.PublisherFtpVerify]
+	switch verify {
+	case "":
+		verify = FtpVerifySize
+	case FtpVerifySize, FtpVerifyChecksum, FtpVerifyNone:
+	default:
+		return 0, fmt.Errorf(ErrFtpVerifyUnknown, verify)
+	}
+	workers, err := strconv.Atoi(f.step.Envs[types.PublisherFtpWorkers])
+	if err != nil || workers <= 0 {
+		workers = ftpDefaultWorkers
+	}
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	c, err := f.operator.Conn()
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range uploadDirs(tasks) {
+		// the directory may have been existed, and the error would be raised by the following STOR anyway
+		if err := c.MakeDir(path.Join(root, v)); err != nil {
+			klog.V(4).Info(err)
+		}
+	}
+	f.operator.Quit(c)
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		jobs     = make(chan uploadTask)
+		stop     = make(chan struct{})
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			close(stop)
+		})
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := f.operator.Conn()
+			if err != nil {
+				fail(err)
+				return
+			}
+			defer f.operator.Quit(c)
+			for t := range jobs {
+				if err := f.storeFile(c, root, t, verify); err != nil {
+					fail(err)
+					return
+				}
+				atomic.AddInt64(&n, t.size)
+				output <- fmt.Sprintf("ftp uploaded %s (%d bytes)", t.target, t.size)
+			}
+		}()
+	}
+feed:
+	for _, t := range tasks {
+		select {
+		case jobs <- t:
+		case <-stop:
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return n, firstErr
+}
+
+// storeFile uploads a single file with the connection and verifies it afterwards
+func (f *ftp) storeFile(c *goftp.ServerConn, root string, t uploadTask, verify string) error {
+	file, err := os.Open(t.source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	h := md5.New()
+	target := path.Join(root, t.target)
+	if err = c.Stor(target, io.TeeReader(file, h)); err != nil {
+		return err
+	}
+	switch verify {
+	case FtpVerifySize:
+		size, err := c.FileSize(target)
+		if err != nil {
+			return err
+		}
+		if size != t.size {
+			return fmt.Errorf(ErrFtpSizeMismatch, target, size, t.size)
+		}
+	case FtpVerifyChecksum:
+		r, err := c.Retr(target)
+		if err != nil {
+			return err
+		}
+		remote := md5.New()
+		_, err = io.Copy(remote, r)
+		if closeErr := r.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		if got, want := hex.EncodeToString(remote.Sum(nil)), hex.EncodeToString(h.Sum(nil)); got != want {
+			return fmt.Errorf(ErrFtpChecksumMismatch, target, got, want)
+		}
+	}
+	return nil
+}
+
+// mkdirRelease creates the release directory in the work dir, and it retries on the collision
+func (f *ftp) mkdirRelease() (dir string, err error) {
+	c, err := f.operator.Conn()
+	if err != nil {
+		return dir, err
+	}
+	defer f.operator.Quit(c)
+	entries, err := c.List(f.config.WorkDir)
 	if err != nil {
-		klog.V(2).Info(err)
 		return dir, err
 	}
-	dir = fmt.Sprintf("%s_%d", date, 1+len(res))
-	return dir, nil
+	existing := make([]string, 0, len(entries))
+	for _, v := range entries {
+		existing = append(existing, path.Base(v.Name))
+	}
+	return createReleaseDir(f.step, existing, func(name string) error {
+		return c.MakeDir(path.Join(f.config.WorkDir, name))
+	})
+}
+
+// updateLatest replaces the content of the latest pointer file with the release directory,
+// the content was written into a temporary file and then renamed, so that readers would never see a partial one
+func (f *ftp) updateLatest(dir string) error {
+	name := f.step.Envs[types.PublisherReleaseLatestFile]
+	if name == "" {
+		return nil
+	}
+	tmp := name + ".tmp"
+	if err := f.operator.WriteFileContent(tmp, []byte(dir)); err != nil {
+		return err
+	}
+	c, err := f.operator.Conn()
+	if err != nil {
+		return err
+	}
+	defer f.operator.Quit(c)
+	from, to := path.Join(f.config.WorkDir, tmp), path.Join(f.config.WorkDir, name)
+	if err = c.Rename(from, to); err != nil {
+		// some servers refuse to overwrite the existing file by RNTO
+		klog.V(4).Info(err)
+		if err = c.Delete(to); err != nil && !isFtpFileUnavailable(err) {
+			return err
+		}
+		if err = c.Rename(from, to); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (f *ftp) Operator() operator.FtpOperator {
@@ -0,0 +1,182 @@
+package operators
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	goftp "github.com/jlaffaye/ftp"
+	"k8s.io/klog/v2"
+)
+
+const (
+	ErrReleaseKeepInvalid    = "error: the %s:%s should be a non-negative integer"
+	releaseRetentionTemplate = "retention: removed %d releases, kept %d"
+	releaseDryRunTemplate    = "retention (dry-run): would remove %d releases, keep %d"
+	releaseRetentionOutput   = "ftp retention removed the release %s"
+	releaseDryRunOutput      = "ftp retention would remove the release %s"
+)
+
+// releaseRetention was the policy parsed from the Envs. A release would be kept if it was one of the latest Keep
+// releases or it was newer than the KeepDays. The retention was disabled if neither of them was set.
+type releaseRetention struct {
+	keep     int
+	keepDays int
+	dryRun   bool
+}
+
+func newReleaseRetention(envs map[string]string) (*releaseRetention, error) {
+	r := &releaseRetention{keep: -1, keepDays: -1}
+	for key, value := range map[string]*int{
+		types.PublisherReleaseKeep:     &r.keep,
+		types.PublisherReleaseKeepDays: &r.keepDays,
+	} {
+		v := strings.TrimSpace(envs[key])
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf(ErrReleaseKeepInvalid, key, v)
+		}
+		*value = n
+	}
+	r.dryRun, _ = strconv.ParseBool(envs[types.PublisherReleasePruneDryRun])
+	return r, nil
+}
+
+func (r *releaseRetention) enabled() bool {
+	return r.keep >= 0 || r.keepDays >= 0
+}
+
+type release struct {
+	name string
+	seq  int
+	time time.Time
+}
+
+// expired returns the releases which were out of the retention, the protected ones would never be returned
+func (r *releaseRetention) expired(releases []release, protected map[string]bool, now time.Time) []release {
+	sort.Slice(releases, func(i, j int) bool {
+		if !releases[i].time.Equal(releases[j].time) {
+			return releases[i].time.After(releases[j].time)
+		}
+		if releases[i].seq != releases[j].seq {
+			return releases[i].seq > releases[j].seq
+		}
+		return releases[i].name > releases[j].name
+	})
+	res := make([]release, 0)
+	for i, v := range releases {
+		if protected[v.name] {
+			continue
+		}
+		if r.keep >= 0 && i < r.keep {
+			continue
+		}
+		if r.keepDays >= 0 && v.time.After(now.Add(-time.Hour*24*time.Duration(r.keepDays))) {
+			continue
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+// prune removes the releases in the work dir which were out of the retention. The current release and the one
+// referenced by the latest pointer file would never be removed.
+func (f *ftp) prune(current string, output chan<- string) error {
+	r, err := newReleaseRetention(f.step.Envs)
+	if err != nil {
+		return err
+	}
+	if !r.enabled() {
+		return nil
+	}
+	pattern, err := releaseDirPattern(f.step)
+	if err != nil {
+		return err
+	}
+	protected := map[string]bool{current: true}
+	if name := f.step.Envs[types.PublisherReleaseLatestFile]; name != "" {
+		latest, err := f.operator.ReadFileContent(name)
+		if err != nil && !isFtpFileUnavailable(err) {
+			return err
+		}
+		if dir := strings.TrimSpace(string(latest)); dir != "" {
+			protected[dir] = true
+		}
+	}
+	c, err := f.operator.Conn()
+	if err != nil {
+		return err
+	}
+	defer f.operator.Quit(c)
+	entries, err := c.List(f.config.WorkDir)
+	if err != nil {
+		return err
+	}
+	releases := make([]release, 0)
+	seqIndex := pattern.SubexpIndex("seq")
+	for _, v := range entries {
+		name := path.Base(v.Name)
+		if v.Type != goftp.EntryTypeFolder {
+			continue
+		}
+		m := pattern.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		rel := release{name: name, time: v.Time}
+		if seqIndex > 0 {
+			rel.seq, _ = strconv.Atoi(m[seqIndex])
+		}
+		releases = append(releases, rel)
+	}
+	expired := r.expired(releases, protected, time.Now())
+	for _, v := range expired {
+		if r.dryRun {
+			output <- fmt.Sprintf(releaseDryRunOutput, v.name)
+			continue
+		}
+		if err = removeFtpDir(c, path.Join(f.config.WorkDir, v.name)); err != nil {
+			return err
+		}
+		output <- fmt.Sprintf(releaseRetentionOutput, v.name)
+	}
+	summary := releaseRetentionTemplate
+	if r.dryRun {
+		summary = releaseDryRunTemplate
+	}
+	f.step.Remarks = append(f.step.Remarks, fmt.Sprintf(summary, len(expired), len(releases)-len(expired)))
+	return nil
+}
+
+// removeFtpDir removes the directory recursively with the absolute paths
+func removeFtpDir(c *goftp.ServerConn, dir string) error {
+	entries, err := c.List(dir)
+	if err != nil {
+		return err
+	}
+	for _, v := range entries {
+		name := path.Base(v.Name)
+		if name == "." || name == ".." {
+			continue
+		}
+		p := path.Join(dir, name)
+		if v.Type == goftp.EntryTypeFolder {
+			if err = removeFtpDir(c, p); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = c.Delete(p); err != nil {
+			return err
+		}
+	}
+	klog.V(4).Infof("ftp remove dir:%s", dir)
+	return c.RemoveDir(dir)
+}
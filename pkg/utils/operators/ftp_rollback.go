@@ -0,0 +1,64 @@
+package operators
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	goftp "github.com/jlaffaye/ftp"
+)
+
+const (
+	ErrFtpRollbackReleaseMissing = "error: the rollback release directory:%s was not existed in the work dir:%s"
+	// ErrFtpRollbackNoRelease was returned when the record was not published into a release directory, because the
+	// local source files may have been changed since then, and re-uploading them would not restore the record
+	ErrFtpRollbackNoRelease    = "error: the record %s was not published into a release directory, it could not be rolled back"
+	ErrFtpRollbackNoLatestFile = "error: the rollback to the release directory:%s required the PUBLISHER_RELEASE_LATEST_FILE"
+	ftpRollbackPointerTemplate = "rollback to the release %s of the record %s"
+)
+
+// rollback consumes the rollback Envs which were set by the Scheduler, so that the following runs would not roll back
+// again, and the record id was kept in the SharingData for linking the rollback record to the original one.
+// It re-points the latest pointer file to the recorded release directory, and fails if either of them was unavailable.
+func (f *ftp) rollback(output chan<- string) error {
+	recordId := f.step.Envs[types.PublisherRollbackRecordId]
+	dir := f.step.Envs[types.PublisherRollbackReleaseDir]
+	delete(f.step.Envs, types.PublisherRollbackRecordId)
+	delete(f.step.Envs, types.PublisherRollbackReleaseDir)
+	f.step.SharingData[types.PublisherRollbackRecordId] = recordId
+	if dir == "" {
+		return fmt.Errorf(ErrFtpRollbackNoRelease, recordId)
+	}
+	if f.step.Envs[types.PublisherReleaseLatestFile] == "" {
+		return fmt.Errorf(ErrFtpRollbackNoLatestFile, dir)
+	}
+	if err := f.checkReleaseDir(dir); err != nil {
+		return err
+	}
+	if err := f.updateLatest(dir); err != nil {
+		return err
+	}
+	publishReleaseDir(f.step, dir)
+	f.step.Remarks = append(f.step.Remarks, fmt.Sprintf(ftpRollbackPointerTemplate, dir, recordId))
+	output <- fmt.Sprintf(ftpRollbackPointerTemplate, dir, recordId)
+	return nil
+}
+
+// checkReleaseDir makes sure the release directory was still existed, it may have been pruned by the retention
+func (f *ftp) checkReleaseDir(dir string) error {
+	c, err := f.operator.Conn()
+	if err != nil {
+		return err
+	}
+	defer f.operator.Quit(c)
+	entries, err := c.List(f.config.WorkDir)
+	if err != nil {
+		return err
+	}
+	for _, v := range entries {
+		if v.Type == goftp.EntryTypeFolder && path.Base(v.Name) == dir {
+			return nil
+		}
+	}
+	return fmt.Errorf(ErrFtpRollbackReleaseMissing, dir, f.config.WorkDir)
+}
@@ -0,0 +1,281 @@
+package operators
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	fakeFtpUsername = "publisher"
+	fakeFtpPassword = "secret"
+)
+
+// fakeFtpServer was an in-process ftp server which serves the passive mode on a local directory
+type fakeFtpServer struct {
+	listener net.Listener
+	root     string
+	// mangle modifies the content of the uploaded files before they were stored, for testing the verification
+	mangle func([]byte) []byte
+
+	mu       sync.Mutex
+	commands map[string]int
+}
+
+func newFakeFtpServer(t *testing.T) *fakeFtpServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeFtpServer{
+		listener: l,
+		root:     t.TempDir(),
+		commands: make(map[string]int, 0),
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return s
+}
+
+func (s *fakeFtpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// count returns how many times the command has been received
+func (s *fakeFtpServer) count(command string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands[command]
+}
+
+// local maps the remote path into the root directory
+func (s *fakeFtpServer) local(p string) string {
+	return filepath.Join(s.root, filepath.FromSlash(path.Clean("/"+p)))
+}
+
+type fakeFtpSession struct {
+	server   *fakeFtpServer
+	conn     net.Conn
+	reader   *bufio.Reader
+	user     string
+	loggedIn bool
+	pasv     net.Listener
+	rename   string
+}
+
+func (s *fakeFtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	se := &fakeFtpSession{server: s, conn: conn, reader: bufio.NewReader(conn)}
+	defer se.closePasv()
+	se.reply(220, "fake ftp server ready")
+	for {
+		line, err := se.reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd, arg := line, ""
+		if i := strings.Index(line, " "); i >= 0 {
+			cmd, arg = line[:i], line[i+1:]
+		}
+		cmd = strings.ToUpper(cmd)
+		s.mu.Lock()
+		s.commands[cmd]++
+		s.mu.Unlock()
+		if cmd == "QUIT" {
+			se.reply(221, "bye")
+			return
+		}
+		se.handle(cmd, arg)
+	}
+}
+
+func (se *fakeFtpSession) reply(code int, msg string) {
+	_, _ = fmt.Fprintf(se.conn, "%d %s\r\n", code, msg)
+}
+
+func (se *fakeFtpSession) closePasv() {
+	if se.pasv != nil {
+		_ = se.pasv.Close()
+		se.pasv = nil
+	}
+}
+
+// transfer accepts the data connection which was prepared by PASV, and hands it to the f
+func (se *fakeFtpSession) transfer(f func(conn net.Conn) error) {
+	if se.pasv == nil {
+		se.reply(425, "use PASV first")
+		return
+	}
+	conn, err := se.pasv.Accept()
+	se.closePasv()
+	if err != nil {
+		se.reply(425, err.Error())
+		return
+	}
+	se.reply(150, "opening data connection")
+	err = f(conn)
+	_ = conn.Close()
+	if err != nil {
+		se.reply(451, err.Error())
+		return
+	}
+	se.reply(226, "transfer complete")
+}
+
+func (se *fakeFtpSession) handle(cmd, arg string) {
+	switch cmd {
+	case "USER":
+		se.user = arg
+		se.reply(331, "password required")
+		return
+	case "PASS":
+		if se.user != fakeFtpUsername || arg != fakeFtpPassword {
+			se.reply(530, "login incorrect")
+			return
+		}
+		se.loggedIn = true
+		se.reply(230, "logged in")
+		return
+	}
+	if !se.loggedIn {
+		se.reply(530, "not logged in")
+		return
+	}
+	p := se.server.local(arg)
+	switch cmd {
+	case "TYPE", "NOOP":
+		se.reply(200, "ok")
+	case "PWD":
+		se.reply(257, `"/"`)
+	case "CWD":
+		if fi, err := os.Stat(p); err != nil || !fi.IsDir() {
+			se.reply(550, "no such directory")
+			return
+		}
+		se.reply(250, "ok")
+	case "PASV":
+		se.closePasv()
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			se.reply(425, err.Error())
+			return
+		}
+		se.pasv = l
+		port := l.Addr().(*net.TCPAddr).Port
+		se.reply(227, fmt.Sprintf("Entering Passive Mode (127,0,0,1,%d,%d).", port/256, port%256))
+	case "MKD":
+		if err := os.Mkdir(p, 0755); err != nil {
+			se.reply(550, err.Error())
+			return
+		}
+		se.reply(257, fmt.Sprintf("%q created", arg))
+	case "RMD":
+		if err := os.Remove(p); err != nil {
+			se.reply(550, err.Error())
+			return
+		}
+		se.reply(250, "ok")
+	case "DELE":
+		if fi, err := os.Stat(p); err != nil || fi.IsDir() {
+			se.reply(550, "no such file")
+			return
+		}
+		if err := os.Remove(p); err != nil {
+			se.reply(550, err.Error())
+			return
+		}
+		se.reply(250, "ok")
+	case "RNFR":
+		if _, err := os.Stat(p); err != nil {
+			se.reply(550, err.Error())
+			return
+		}
+		se.rename = p
+		se.reply(350, "ready for RNTO")
+	case "RNTO":
+		if err := os.Rename(se.rename, p); err != nil {
+			se.reply(550, err.Error())
+			return
+		}
+		se.reply(250, "ok")
+	case "SIZE":
+		fi, err := os.Stat(p)
+		if err != nil || fi.IsDir() {
+			se.reply(550, "no such file")
+			return
+		}
+		se.reply(213, fmt.Sprintf("%d", fi.Size()))
+	case "STOR":
+		if _, err := os.Stat(filepath.Dir(p)); err != nil {
+			se.reply(553, err.Error())
+			se.closePasv()
+			return
+		}
+		se.transfer(func(conn net.Conn) error {
+			content, err := ioutil.ReadAll(conn)
+			if err != nil {
+				return err
+			}
+			if se.server.mangle != nil {
+				content = se.server.mangle(content)
+			}
+			return ioutil.WriteFile(p, content, 0644)
+		})
+	case "RETR":
+		f, err := os.Open(p)
+		if err != nil {
+			se.reply(550, err.Error())
+			se.closePasv()
+			return
+		}
+		defer f.Close()
+		se.transfer(func(conn net.Conn) error {
+			_, err := io.Copy(conn, f)
+			return err
+		})
+	case "LIST", "NLST":
+		items, err := ioutil.ReadDir(p)
+		if err != nil {
+			se.reply(550, err.Error())
+			se.closePasv()
+			return
+		}
+		se.transfer(func(conn net.Conn) error {
+			for _, v := range items {
+				line := v.Name()
+				if cmd == "LIST" {
+					t := "file"
+					if v.IsDir() {
+						t = "dir"
+					}
+					line = fmt.Sprintf("type=%s;size=%d;modify=%s; %s", t, v.Size(), v.ModTime().UTC().Format("20060102150405"), v.Name())
+				}
+				if _, err := fmt.Fprintf(conn, "%s\r\n", line); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		se.reply(502, "command not implemented")
+	}
+}
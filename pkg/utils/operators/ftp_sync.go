@@ -0,0 +1,182 @@
+package operators
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	FtpSyncIncremental = "incremental"
+	// FtpManifestFile was the manifest of the last published files, it was stored in the root of each target
+	FtpManifestFile = ".publisher-manifest.json"
+
+	ErrFtpManifestUnmarshal = "error: failed to parse the ftp manifest:%s err:%v"
+	ftpSyncSummaryTemplate  = "incremental sync: %d added, %d changed, %d removed, %d stale, %d unchanged"
+	ftpFileUnavailable      = 550
+)
+
+// ftpManifest records the files which have been published to a target
+type ftpManifest struct {
+	UpdatedAt time.Time                   `json:"updatedAt"`
+	Files     map[string]ftpManifestEntry `json:"files"`
+}
+
+type ftpManifestEntry struct {
+	Size int64  `json:"size"`
+	MD5  string `json:"md5"`
+}
+
+// ftpSyncPlan was the difference between the local files and the remote manifest
+type ftpSyncPlan struct {
+	uploads   []uploadTask
+	added     int
+	changed   int
+	unchanged int
+	// removed were the files in the manifest but no longer present locally
+	removed  []string
+	last     *ftpManifest
+	manifest *ftpManifest
+}
+
+// planSync compares the tasks with the manifest in the target, and only the added or changed ones would be uploaded
+func (f *ftp) planSync(prefix string, tasks []uploadTask) (*ftpSyncPlan, error) {
+	last, err := f.readManifest(prefix)
+	if err != nil {
+		return nil, err
+	}
+	plan := &ftpSyncPlan{
+		uploads:  make([]uploadTask, 0),
+		removed:  make([]string, 0),
+		last:     last,
+		manifest: &ftpManifest{Files: make(map[string]ftpManifestEntry, len(tasks))},
+	}
+	for _, t := range tasks {
+		sum, err := md5File(t.source)
+		if err != nil {
+			return nil, err
+		}
+		entry := ftpManifestEntry{Size: t.size, MD5: sum}
+		plan.manifest.Files[t.target] = entry
+		old, ok := last.Files[t.target]
+		switch {
+		case !ok:
+			plan.added++
+		case old != entry:
+			plan.changed++
+		default:
+			plan.unchanged++
+			continue
+		}
+		plan.uploads = append(plan.uploads, t)
+	}
+	for k := range last.Files {
+		if _, ok := plan.manifest.Files[k]; !ok {
+			plan.removed = append(plan.removed, k)
+		}
+	}
+	sort.Strings(plan.removed)
+	return plan, nil
+}
+
+// splitUploadTasks separates the planned uploads into the ones of the UploadFiles and the ones of the WriteFiles
+func splitUploadTasks(uploads, writes []uploadTask) (files, writeFiles []uploadTask) {
+	m := make(map[string]struct{}, len(writes))
+	for _, v := range writes {
+		m[v.source] = struct{}{}
+	}
+	files, writeFiles = make([]uploadTask, 0, len(uploads)), make([]uploadTask, 0, len(writes))
+	for _, v := range uploads {
+		if _, ok := m[v.source]; ok {
+			writeFiles = append(writeFiles, v)
+		} else {
+			files = append(files, v)
+		}
+	}
+	return files, writeFiles
+}
+
+// finishSync deletes the removed files if it was required, and then writes the new manifest into the target.
+// The removed files would be kept in the manifest when they were not deleted, so that they could be deleted later.
+func (f *ftp) finishSync(prefix string, plan *ftpSyncPlan, output chan<- string) error {
+	deleted := 0
+	if del, _ := strconv.ParseBool(f.step.Envs[types.PublisherFtpSyncDelete]); del && len(plan.removed) > 0 {
+		c, err := f.operator.Conn()
+		if err != nil {
+			return err
+		}
+		defer f.operator.Quit(c)
+		root := path.Join(f.config.WorkDir, prefix)
+		for _, v := range plan.removed {
+			if err := c.Delete(path.Join(root, v)); err != nil && !isFtpFileUnavailable(err) {
+				return err
+			}
+			deleted++
+			output <- fmt.Sprintf("ftp deleted %s", v)
+		}
+	} else {
+		for _, v := range plan.removed {
+			plan.manifest.Files[v] = plan.last.Files[v]
+		}
+	}
+	plan.manifest.UpdatedAt = time.Now()
+	content, err := json.MarshalIndent(plan.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = f.operator.WriteFileContent(path.Join(prefix, FtpManifestFile), content); err != nil {
+		return err
+	}
+	f.step.Remarks = append(f.step.Remarks, fmt.Sprintf(ftpSyncSummaryTemplate,
+		plan.added, plan.changed, deleted, len(plan.removed)-deleted, plan.unchanged))
+	return nil
+}
+
+// readManifest returns an empty manifest if it didn't exist in the target
+func (f *ftp) readManifest(prefix string) (*ftpManifest, error) {
+	m := &ftpManifest{Files: make(map[string]ftpManifestEntry, 0)}
+	content, err := f.operator.ReadFileContent(path.Join(prefix, FtpManifestFile))
+	if err != nil {
+		if isFtpFileUnavailable(err) {
+			klog.V(4).Info(err)
+			return m, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf(ErrFtpManifestUnmarshal, FtpManifestFile, err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]ftpManifestEntry, 0)
+	}
+	return m, nil
+}
+
+func isFtpFileUnavailable(err error) bool {
+	e, ok := err.(*textproto.Error)
+	return ok && e.Code == ftpFileUnavailable
+}
+
+func md5File(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
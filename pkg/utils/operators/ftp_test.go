@@ -0,0 +1,605 @@
+package operators
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+// newUploadSource creates a tree of the asset files for uploading
+func newUploadSource(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"assets/a.bundle":        "aaa",
+		"assets/b.bundle":        "bbbb",
+		"assets/sub/c.bundle":    "ccccc",
+		"assets/sub/deep/d.json": "{}",
+		"bin/app.apk":            "apk",
+		"bin/app.ipa":            "ipa",
+	}
+	for k, v := range files {
+		p := filepath.Join(dir, filepath.FromSlash(k))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_expandUploadFiles(t *testing.T) {
+	dir := newUploadSource(t)
+	tests := []struct {
+		name     string
+		files    []types.UploadFile
+		want     []string
+		wantDirs []string
+		wantErr  bool
+	}{
+		{
+			name:     "Test_expandUploadFiles_1",
+			files:    []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.apk"), TargetFile: "android/app.apk"}},
+			want:     []string{"android/app.apk"},
+			wantDirs: []string{"android"},
+		},
+		{
+			name:     "Test_expandUploadFiles_2",
+			files:    []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.ipa"), TargetPath: "ios"}},
+			want:     []string{"ios/app.ipa"},
+			wantDirs: []string{"ios"},
+		},
+		{
+			name:     "Test_expandUploadFiles_3",
+			files:    []types.UploadFile{{SourceFile: filepath.Join(dir, "assets"), TargetPath: "res"}},
+			want:     []string{"res/a.bundle", "res/b.bundle", "res/sub/c.bundle", "res/sub/deep/d.json"},
+			wantDirs: []string{"res", "res/sub", "res/sub/deep"},
+		},
+		{
+			name:     "Test_expandUploadFiles_4",
+			files:    []types.UploadFile{{SourceFile: filepath.Join(dir, "assets/*.bundle")}},
+			want:     []string{"a.bundle", "b.bundle"},
+			wantDirs: []string{},
+		},
+		{
+			name: "Test_expandUploadFiles_5",
+			files: []types.UploadFile{
+				{SourceFile: filepath.Join(dir, "*/sub"), TargetPath: "res"},
+				{SourceFile: filepath.Join(dir, "bin/app.apk"), TargetFile: "res/assets/sub/c.bundle"},
+			},
+			want:     []string{"res/assets/sub/c.bundle", "res/assets/sub/deep/d.json"},
+			wantDirs: []string{"res", "res/assets", "res/assets/sub", "res/assets/sub/deep"},
+		},
+		{
+			name:    "Test_expandUploadFiles_6",
+			files:   []types.UploadFile{{SourceFile: filepath.Join(dir, "assets/*.zip"), TargetPath: "res"}},
+			wantErr: true,
+		},
+		{
+			name:    "Test_expandUploadFiles_7",
+			files:   []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.apk")}},
+			wantErr: true,
+		},
+		{
+			name:    "Test_expandUploadFiles_8",
+			files:   []types.UploadFile{{SourceFile: filepath.Join(dir, "missing"), TargetPath: "res"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandUploadFiles(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expandUploadFiles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			targets := make([]string, 0, len(got))
+			for _, v := range got {
+				targets = append(targets, v.target)
+			}
+			sort.Strings(targets)
+			if !reflect.DeepEqual(targets, tt.want) {
+				t.Errorf("expandUploadFiles() = %v, want %v", targets, tt.want)
+			}
+			if dirs := uploadDirs(got); !reflect.DeepEqual(dirs, tt.wantDirs) {
+				t.Errorf("uploadDirs() = %v, want %v", dirs, tt.wantDirs)
+			}
+		})
+	}
+}
+
+func Test_ftp_Run(t *testing.T) {
+	dir := newUploadSource(t)
+	type fields struct {
+		workers string
+		verify  string
+		mkdir   bool
+		mangle  func([]byte) []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name:    "Test_ftp_Run_1",
+			fields:  fields{},
+			wantErr: false,
+		},
+		{
+			name:    "Test_ftp_Run_2",
+			fields:  fields{workers: "3", verify: FtpVerifyChecksum, mkdir: true},
+			wantErr: false,
+		},
+		{
+			name:    "Test_ftp_Run_3",
+			fields:  fields{workers: "1", verify: FtpVerifyNone},
+			wantErr: false,
+		},
+		{
+			name: "Test_ftp_Run_4",
+			fields: fields{mangle: func(b []byte) []byte {
+				return b[:len(b)-1]
+			}},
+			wantErr: true,
+		},
+		{
+			name: "Test_ftp_Run_5",
+			fields: fields{verify: FtpVerifyChecksum, mangle: func(b []byte) []byte {
+				return []byte(strings.ToUpper(string(b)))
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "Test_ftp_Run_6",
+			fields:  fields{verify: "sha512"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeFtpServer(t)
+			server.mangle = tt.fields.mangle
+			f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+			f.step.Envs[types.PublisherFtpWorkers] = tt.fields.workers
+			f.step.Envs[types.PublisherFtpVerify] = tt.fields.verify
+			if tt.fields.mkdir {
+				f.step.Envs[types.PublisherFtpMkdir] = FtpMkdirMark
+			}
+			f.step.UploadFiles = []types.UploadFile{
+				{SourceFile: filepath.Join(dir, "assets"), TargetPath: "res"},
+				{SourceFile: filepath.Join(dir, "bin/*.apk"), TargetPath: "android"},
+			}
+			f.step.WriteFiles = []types.WriteFile{{Content: []byte("1.0.0"), TargetFile: "version.txt"}}
+			f.Prepare()
+			_, err := f.Run(make(chan string, 4096))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ftp.Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if f.step.Phase != types.StepFailed {
+					t.Errorf("ftp.Run() phase = %v, want %v", f.step.Phase, types.StepFailed)
+				}
+				return
+			}
+			root := server.root
+			if tt.fields.mkdir {
+				root = filepath.Join(root, f.step.Envs[types.PublisherFtpMkdir])
+			}
+			for file, want := range map[string]string{
+				"res/a.bundle":        "aaa",
+				"res/sub/deep/d.json": "{}",
+				"android/app.apk":     "apk",
+				"version.txt":         "1.0.0",
+			} {
+				got, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(file)))
+				if err != nil {
+					t.Errorf("ftp.Run() file:%s err:%v", file, err)
+					continue
+				}
+				if string(got) != want {
+					t.Errorf("ftp.Run() file:%s = %s, want %s", file, got, want)
+				}
+			}
+			if len(f.step.Remarks) != 1 || !strings.HasPrefix(f.step.Remarks[0], "uploaded 6 files, 22 B in ") {
+				t.Errorf("ftp.Run() remarks = %v", f.step.Remarks)
+			}
+		})
+	}
+}
+
+func Test_ftp_Run_writeFiles(t *testing.T) {
+	server := newFakeFtpServer(t)
+	tests := []struct {
+		name       string
+		sync       bool
+		mangle     func([]byte) []byte
+		wantRemark string
+		wantStors  int
+		wantErr    bool
+	}{
+		{
+			name:       "Test_ftp_Run_writeFiles_1",
+			wantRemark: "uploaded 2 files, 22 B in ",
+			wantStors:  2,
+		},
+		{
+			name:       "Test_ftp_Run_writeFiles_2",
+			sync:       true,
+			wantRemark: "incremental sync: 2 added, 0 changed, 0 removed, 0 stale, 0 unchanged",
+			wantStors:  3,
+		},
+		{
+			name:       "Test_ftp_Run_writeFiles_3",
+			sync:       true,
+			wantRemark: "incremental sync: 0 added, 0 changed, 0 removed, 0 stale, 2 unchanged",
+			wantStors:  1,
+		},
+		{
+			name: "Test_ftp_Run_writeFiles_4",
+			mangle: func(b []byte) []byte {
+				return b[:len(b)-1]
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.mangle = tt.mangle
+			before := server.count("STOR")
+			f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+			if tt.sync {
+				f.step.Envs[types.PublisherFtpSync] = FtpSyncIncremental
+			}
+			f.step.WriteFiles = []types.WriteFile{
+				{Content: []byte(`{"version":"1.0.0"}`), TargetFile: "conf/version.json"},
+				{Content: []byte("1.0"), TargetFile: "a/b/c.txt"},
+			}
+			f.Prepare()
+			_, err := f.Run(make(chan string, 4096))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ftp.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := server.count("STOR") - before; got != tt.wantStors {
+				t.Errorf("ftp.Run() STOR = %v, want %v", got, tt.wantStors)
+			}
+			if len(f.step.Remarks) == 0 || !strings.HasPrefix(f.step.Remarks[0], tt.wantRemark) {
+				t.Errorf("ftp.Run() remarks = %v, want %v", f.step.Remarks, tt.wantRemark)
+			}
+			got, err := ioutil.ReadFile(filepath.Join(server.root, "conf", "version.json"))
+			if err != nil || string(got) != `{"version":"1.0.0"}` {
+				t.Errorf("ftp.Run() conf/version.json = %s, err = %v", got, err)
+			}
+		})
+	}
+}
+
+func Test_ftp_Run_incremental(t *testing.T) {
+	dir := newUploadSource(t)
+	server := newFakeFtpServer(t)
+	assets := filepath.Join(dir, "assets")
+	tests := []struct {
+		name        string
+		change      func() error
+		delete      string
+		wantRemark  string
+		wantRemoved bool
+		wantUploads int
+	}{
+		{
+			name:        "Test_ftp_Run_incremental_1",
+			change:      func() error { return nil },
+			wantRemark:  "incremental sync: 4 added, 0 changed, 0 removed, 0 stale, 0 unchanged",
+			wantUploads: 4,
+		},
+		{
+			name:        "Test_ftp_Run_incremental_2",
+			change:      func() error { return nil },
+			wantRemark:  "incremental sync: 0 added, 0 changed, 0 removed, 0 stale, 4 unchanged",
+			wantUploads: 0,
+		},
+		{
+			name: "Test_ftp_Run_incremental_3",
+			change: func() error {
+				if err := ioutil.WriteFile(filepath.Join(assets, "a.bundle"), []byte("aab"), 0644); err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(filepath.Join(assets, "e.bundle"), []byte("e"), 0644); err != nil {
+					return err
+				}
+				return os.Remove(filepath.Join(assets, "b.bundle"))
+			},
+			wantRemark:  "incremental sync: 1 added, 1 changed, 0 removed, 1 stale, 2 unchanged",
+			wantUploads: 2,
+		},
+		{
+			name:        "Test_ftp_Run_incremental_4",
+			change:      func() error { return nil },
+			delete:      "true",
+			wantRemark:  "incremental sync: 0 added, 0 changed, 1 removed, 0 stale, 4 unchanged",
+			wantRemoved: true,
+			wantUploads: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change(); err != nil {
+				t.Fatal(err)
+			}
+			before := server.count("STOR")
+			f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+			f.step.Envs[types.PublisherFtpSync] = FtpSyncIncremental
+			f.step.Envs[types.PublisherFtpSyncDelete] = tt.delete
+			f.step.UploadFiles = []types.UploadFile{{SourceFile: assets, TargetPath: "res"}}
+			f.Prepare()
+			if _, err := f.Run(make(chan string, 4096)); err != nil {
+				t.Fatalf("ftp.Run() error = %v", err)
+			}
+			// the manifest was always written after the uploads
+			if got := server.count("STOR") - before - 1; got != tt.wantUploads {
+				t.Errorf("ftp.Run() uploads = %v, want %v", got, tt.wantUploads)
+			}
+			if len(f.step.Remarks) != 2 || f.step.Remarks[0] != tt.wantRemark {
+				t.Errorf("ftp.Run() remarks = %v, want %v", f.step.Remarks, tt.wantRemark)
+			}
+			_, err := os.Stat(filepath.Join(server.root, "res", "b.bundle"))
+			if removed := os.IsNotExist(err); removed != tt.wantRemoved {
+				t.Errorf("ftp.Run() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func Test_ftp_Run_release(t *testing.T) {
+	dir := newUploadSource(t)
+	server := newFakeFtpServer(t)
+	date := time.Now().Format(stepTemplateDateFormat)
+	// the unrelated file and the directory created by others would never be reused
+	if err := ioutil.WriteFile(filepath.Join(server.root, date+"_notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(server.root, date+"_2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{date + "_1", date + "_3"} {
+		f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+		f.step.Envs[types.PublisherFtpMkdir] = FtpMkdirMark
+		f.step.Envs[types.PublisherReleaseLatestFile] = "latest.txt"
+		f.step.UploadFiles = []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.apk"), TargetPath: "android"}}
+		f.Prepare()
+		if _, err := f.Run(make(chan string, 4096)); err != nil {
+			t.Fatalf("ftp.Run() error = %v", err)
+		}
+		if got := f.step.SharingData[types.PublisherReleaseDir]; got != want {
+			t.Errorf("ftp.Run() release dir = %v, want %v", got, want)
+		}
+		if _, err := os.Stat(filepath.Join(server.root, want, "android", "app.apk")); err != nil {
+			t.Errorf("ftp.Run() err:%v", err)
+		}
+		latest, err := ioutil.ReadFile(filepath.Join(server.root, "latest.txt"))
+		if err != nil || string(latest) != want {
+			t.Errorf("ftp.Run() latest = %s err:%v, want %v", latest, err, want)
+		}
+	}
+	// the incremental sync would upload everything into the new release directory, so that it was refused
+	f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+	f.step.Envs[types.PublisherFtpMkdir] = FtpMkdirMark
+	f.step.Envs[types.PublisherFtpSync] = FtpSyncIncremental
+	f.step.UploadFiles = []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.apk"), TargetPath: "android"}}
+	f.Prepare()
+	if _, err := f.Run(make(chan string, 4096)); err == nil || err.Error() != ErrFtpSyncWithRelease {
+		t.Errorf("ftp.Run() error = %v, want %v", err, ErrFtpSyncWithRelease)
+	}
+	if _, err := os.Stat(filepath.Join(server.root, date+"_4")); !os.IsNotExist(err) {
+		t.Errorf("ftp.Run() created the release directory, err:%v", err)
+	}
+}
+
+func Test_ftp_Run_retention(t *testing.T) {
+	dir := newUploadSource(t)
+	server := newFakeFtpServer(t)
+	now := time.Now()
+	old := map[string]time.Duration{
+		"20201001_1": time.Hour * 24 * 30,
+		"20201020_1": time.Hour * 24 * 10,
+		"20201028_1": time.Hour * 24 * 2,
+	}
+	for k, v := range old {
+		p := filepath.Join(server.root, k)
+		if err := os.MkdirAll(filepath.Join(p, "android"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(p, "android", "app.apk"), []byte("apk"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, now.Add(-v), now.Add(-v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the directory which was not a release would never be removed
+	if err := os.MkdirAll(filepath.Join(server.root, "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// the latest pointer would be replaced by the current release before the retention
+	if err := ioutil.WriteFile(filepath.Join(server.root, "latest.txt"), []byte("20201001_1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		envs       map[string]string
+		dryRun     string
+		wantRemark string
+		wantOutput string
+		wantExists map[string]bool
+	}{
+		{
+			name:       "Test_ftp_Run_retention_1",
+			dryRun:     "true",
+			wantRemark: "retention (dry-run): would remove 2 releases, keep 2",
+			wantExists: map[string]bool{"20201001_1": true, "20201020_1": true, "20201028_1": true},
+		},
+		{
+			name:       "Test_ftp_Run_retention_2",
+			wantRemark: "retention: removed 3 releases, kept 2",
+			wantExists: map[string]bool{"20201001_1": false, "20201020_1": false, "20201028_1": false, "assets": true},
+		},
+		{
+			name:       "Test_ftp_Run_retention_3",
+			envs:       map[string]string{types.PublisherReleaseDirTemplate: "{{.Version}}", types.PublisherVersion: "1.0.0"},
+			wantOutput: "ftp retention err:" + fmt.Sprintf(ErrReleaseDirPattern, "{{.Version}}"),
+			wantExists: map[string]bool{"assets": true, "1.0.0": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+			f.step.Envs[types.PublisherFtpMkdir] = FtpMkdirMark
+			f.step.Envs[types.PublisherReleaseLatestFile] = "latest.txt"
+			f.step.Envs[types.PublisherReleaseKeep] = "2"
+			f.step.Envs[types.PublisherReleasePruneDryRun] = tt.dryRun
+			for k, v := range tt.envs {
+				f.step.Envs[k] = v
+			}
+			f.step.UploadFiles = []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.apk"), TargetPath: "android"}}
+			f.Prepare()
+			output := make(chan string, 4096)
+			if _, err := f.Run(output); err != nil {
+				t.Fatalf("ftp.Run() error = %v", err)
+			}
+			if got := f.step.Remarks[len(f.step.Remarks)-1]; tt.wantRemark != "" && got != tt.wantRemark {
+				t.Errorf("ftp.Run() remark = %v, want %v", got, tt.wantRemark)
+			}
+			for k, want := range tt.wantExists {
+				_, err := os.Stat(filepath.Join(server.root, k))
+				if got := err == nil; got != want {
+					t.Errorf("ftp.Run() release:%s exists = %v, want %v", k, got, want)
+				}
+			}
+			if tt.dryRun != "" {
+				close(output)
+				lines := make([]string, 0)
+				for v := range output {
+					if strings.HasPrefix(v, "ftp retention would remove") {
+						lines = append(lines, v)
+					}
+				}
+				if len(lines) != 2 {
+					t.Errorf("ftp.Run() dry-run output = %v", lines)
+				}
+			}
+			if tt.wantOutput != "" {
+				close(output)
+				found := false
+				for v := range output {
+					found = found || v == tt.wantOutput
+				}
+				if !found {
+					t.Errorf("ftp.Run() output want %v", tt.wantOutput)
+				}
+			}
+		})
+	}
+}
+
+func Test_ftp_Run_rollback(t *testing.T) {
+	dir := newUploadSource(t)
+	server := newFakeFtpServer(t)
+	for _, v := range []string{"20201028_1", "20201030_1"} {
+		if err := os.Mkdir(filepath.Join(server.root, v), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(server.root, "latest.txt"), []byte("20201030_1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	type fields struct {
+		releaseDir string
+		latestFile string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		wantLatest string
+		wantErr    bool
+	}{
+		{
+			name:       "Test_ftp_Run_rollback_1",
+			fields:     fields{releaseDir: "20201028_1", latestFile: "latest.txt"},
+			wantLatest: "20201028_1",
+			wantErr:    false,
+		},
+		{
+			name:       "Test_ftp_Run_rollback_2",
+			fields:     fields{releaseDir: "20201001_1", latestFile: "latest.txt"},
+			wantLatest: "20201028_1",
+			wantErr:    true,
+		},
+		{
+			name:       "Test_ftp_Run_rollback_3",
+			fields:     fields{releaseDir: "20201030_1"},
+			wantLatest: "20201028_1",
+			wantErr:    true,
+		},
+		{
+			name:       "Test_ftp_Run_rollback_4",
+			fields:     fields{latestFile: "latest.txt"},
+			wantLatest: "20201028_1",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFtp("127.0.0.1", server.port(), fakeFtpUsername, fakeFtpPassword, "/", 5)
+			f.step.Envs[types.PublisherFtpMkdir] = tt.fields.releaseDir
+			f.step.Envs[types.PublisherReleaseLatestFile] = tt.fields.latestFile
+			f.step.Envs[types.PublisherRollbackRecordId] = "42"
+			f.step.Envs[types.PublisherRollbackReleaseDir] = tt.fields.releaseDir
+			f.step.UploadFiles = []types.UploadFile{{SourceFile: filepath.Join(dir, "bin/app.apk"), TargetPath: "android"}}
+			f.Prepare()
+			_, err := f.Run(make(chan string, 4096))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ftp.Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			latest, err := ioutil.ReadFile(filepath.Join(server.root, "latest.txt"))
+			if err != nil || string(latest) != tt.wantLatest {
+				t.Errorf("ftp.Run() latest = %s err:%v, want %v", latest, err, tt.wantLatest)
+			}
+			// the current source files should never be uploaded as the rolled back release
+			_ = filepath.Walk(server.root, func(name string, info os.FileInfo, err error) error {
+				if err == nil && info.Name() == "app.apk" {
+					t.Errorf("ftp.Run() uploaded %s during the rollback", name)
+				}
+				return nil
+			})
+			if tt.wantErr {
+				return
+			}
+			if _, ok := f.step.Envs[types.PublisherRollbackRecordId]; ok {
+				t.Errorf("ftp.Run() the rollback Envs were not consumed: %v", f.step.Envs)
+			}
+			if got := f.step.SharingData[types.PublisherRollbackRecordId]; got != "42" {
+				t.Errorf("ftp.Run() rollback record id = %v, want 42", got)
+			}
+			if got := f.step.SharingData[types.PublisherReleaseDir]; got != tt.fields.releaseDir {
+				t.Errorf("ftp.Run() release = %v, want %v", got, tt.fields.releaseDir)
+			}
+		})
+	}
+}
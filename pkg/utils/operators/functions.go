@@ -2,39 +2,186 @@ package operators
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"k8s.io/klog/v2"
+	"io/ioutil"
 	"os/exec"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// StderrPrefix tags the stderr lines in the output, the stdout lines were not tagged
+	StderrPrefix = "[stderr] "
+	// ExecStderrTail was the number of the last stderr lines which would be kept in the ExecError
+	ExecStderrTail = 20
 )
 
+// DefaultExec runs the commands by the `sh -c` and returns the stdout. The combined output of the stdout and the
+// stderr would be returned with the ExecError if the commands failed.
 func DefaultExec(commands string) (res []byte, err error) {
-	return exec.CommandContext(context.Background(), "sh", "-c", commands).Output()
+	cmd := exec.CommandContext(context.Background(), "sh", "-c", commands)
+	var stdout bytes.Buffer
+	combined := &lockedBuffer{}
+	cmd.Stdout = io.MultiWriter(&stdout, combined)
+	cmd.Stderr = combined
+	if err = cmd.Run(); err != nil {
+		e := &ExecError{Args: cmd.Args, ExitCode: -1, Stderr: combined.String(), Err: err}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			e.ExitCode = exitErr.ExitCode()
+		}
+		klog.V(2).Info(e)
+		return combined.Bytes(), e
+	}
+	return stdout.Bytes(), nil
 }
 
+// ExecWithStreamOutput runs the commands by the `sh -c`, streams the stdout and the stderr lines into the output
+// in the order they were read, and returns the stdout
 func ExecWithStreamOutput(commands string, output chan<- string) (res []byte, err error) {
-	//return exec.CommandContext(context.Background(), "sh", "-c", commands).Output()
 	cmd := exec.CommandContext(context.Background(), "sh", "-c", commands)
-	var stdout io.ReadCloser
+	return execCmdWithStreamOutput(cmd, output)
+}
+
+// lockedBuffer was the buffer which could be written by the stdout and the stderr copying goroutines at the same time
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Bytes()
+}
+
+func (b *lockedBuffer) String() string {
+	return string(b.Bytes())
+}
+
+// ExecError was the error of a command which has been started, it carries the exit code and the stderr
+type ExecError struct {
+	Args     []string
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("exec `%s` exit code:%d err:%v stderr:%s", strings.Join(e.Args, " "), e.ExitCode, e.Err, e.Stderr)
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// ExecArgsWithStreamOutput runs the command with the argument vector in the dir without a shell,
+// so that none of the args would be interpreted. The stdout and the stderr would be streamed into the output line by
+// line, and the stdout would also be returned.
+func ExecArgsWithStreamOutput(dir string, output chan<- string, name string, args ...string) (res []byte, err error) {
+	cmd := exec.CommandContext(context.Background(), name, args...)
+	cmd.Dir = dir
+	return execCmdWithStreamOutput(cmd, output)
+}
+
+// execCmdWithStreamOutput streams the stdout and the stderr lines of the cmd into the output, the stderr lines were
+// tagged by the StderrPrefix. It returns the stdout, and the ExecError with the last stderr lines if the cmd failed.
+func execCmdWithStreamOutput(cmd *exec.Cmd, output chan<- string) (res []byte, err error) {
+	var buf bytes.Buffer
+	err = execCmdWithStreams(cmd, ExecStderrTail, func(line StreamLine) {
+		if line.Stderr {
+			output <- StderrPrefix + line.Text
+			return
+		}
+		buf.WriteString(line.Text)
+		buf.WriteByte('\n')
+		output <- line.Text
+	})
+	return buf.Bytes(), err
+}
+
+// Messages returns the exit code and the last stderr lines, they would be appended into the Step.Messages
+func (e *ExecError) Messages() []string {
+	res := []string{fmt.Sprintf("exit code: %d", e.ExitCode)}
+	if e.Stderr != "" {
+		res = append(res, strings.Split(e.Stderr, "\n")...)
+	}
+	return res
+}
+
+// StreamLine was a line of the stdout or the stderr of a command
+type StreamLine struct {
+	Stderr bool
+	Text   string
+}
+
+// execCmdWithStreams runs the cmd and calls the fc with the lines of the stdout and the stderr in the order they were
+// read. The ExecError would carry the exit code and the last stderrTail lines of the stderr if the cmd failed.
+func execCmdWithStreams(cmd *exec.Cmd, stderrTail int, fc func(line StreamLine)) (err error) {
+	var stdout, stderr io.ReadCloser
 	if stdout, err = cmd.StdoutPipe(); err != nil {
-		klog.V(2).Info(err)
-		return res, err
+		return err
+	}
+	if stderr, err = cmd.StderrPipe(); err != nil {
+		return err
 	}
 	if err = cmd.Start(); err != nil {
 		klog.V(2).Info(err)
-		return res, err
+		return err
+	}
+	lines := make(chan StreamLine)
+	scan := func(r io.Reader, isStderr bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- StreamLine{Stderr: isStderr, Text: scanner.Text()}
+		}
+		// drain the rest if the scanner stopped at a too long line, so that the command would not be blocked
+		_, _ = io.Copy(ioutil.Discard, r)
+		lines <- StreamLine{Stderr: isStderr, Text: streamEOF}
 	}
-	scanner := bufio.NewScanner(stdout)
-	scanner.Split(bufio.ScanLines)
-	for scanner.Scan() {
-		m := scanner.Text()
-		output <- m
-		fmt.Println(m)
+	go scan(stdout, false)
+	go scan(stderr, true)
+	tail := make([]string, 0, stderrTail)
+	for open := 2; open > 0; {
+		line := <-lines
+		if line.Text == streamEOF {
+			open--
+			continue
+		}
+		if line.Stderr && stderrTail > 0 {
+			if len(tail) == stderrTail {
+				tail = tail[1:]
+			}
+			tail = append(tail, line.Text)
+		}
+		fc(line)
 	}
 	if err = cmd.Wait(); err != nil {
-		klog.V(2).Info(err)
-		return res, err
+		e := &ExecError{
+			Args:     cmd.Args,
+			ExitCode: -1,
+			Stderr:   strings.Join(tail, "\n"),
+			Err:      err,
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			e.ExitCode = exitErr.ExitCode()
+		}
+		klog.V(2).Info(e)
+		return e
 	}
-	return res, nil
+	return nil
 }
+
+// streamEOF was sent by the scanner when the stream was closed, it could never be a scanned line
+const streamEOF = "\n"
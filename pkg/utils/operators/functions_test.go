@@ -0,0 +1,113 @@
+package operators
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDefaultExec(t *testing.T) {
+	tests := []struct {
+		name         string
+		commands     string
+		wantRes      string
+		wantExitCode int
+		wantErr      bool
+	}{
+		{
+			name:     "TestDefaultExec_1",
+			commands: "echo out; echo err >&2",
+			wantRes:  "out\n",
+		},
+		{
+			name:         "TestDefaultExec_2",
+			commands:     "echo out; echo err >&2; exit 128",
+			wantRes:      "out\nerr\n",
+			wantExitCode: 128,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRes, err := DefaultExec(tt.commands)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DefaultExec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// the stdout and the stderr were copied concurrently, so the order between them was not guaranteed
+			got, want := strings.Split(string(gotRes), "\n"), strings.Split(tt.wantRes, "\n")
+			sort.Strings(got)
+			sort.Strings(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("DefaultExec() = %q, want %q", gotRes, tt.wantRes)
+			}
+			var e *ExecError
+			if tt.wantErr && (!errors.As(err, &e) || e.ExitCode != tt.wantExitCode) {
+				t.Errorf("DefaultExec() error = %#v, want the exit code %d", err, tt.wantExitCode)
+			}
+		})
+	}
+}
+
+func TestExecWithStreamOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		commands     string
+		wantRes      string
+		wantOutput   []string
+		wantMessages []string
+	}{
+		{
+			name:       "TestExecWithStreamOutput_1",
+			commands:   "echo a; echo b >&2; echo c",
+			wantRes:    "a\nc\n",
+			wantOutput: []string{"a", StderrPrefix + "b", "c"},
+		},
+		{
+			name:         "TestExecWithStreamOutput_2",
+			commands:     "echo a; for i in $(seq 1 25); do echo \"line $i\" >&2; done; exit 3",
+			wantRes:      "a\n",
+			wantMessages: []string{"exit code: 3", "line 6", "line 25"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := make(chan string, 4096)
+			gotRes, err := ExecWithStreamOutput(tt.commands, output)
+			close(output)
+			if string(gotRes) != tt.wantRes {
+				t.Errorf("ExecWithStreamOutput() = %q, want %q", gotRes, tt.wantRes)
+			}
+			got := make([]string, 0)
+			for v := range output {
+				got = append(got, v)
+			}
+			if tt.wantOutput != nil {
+				// the stdout and the stderr were read concurrently, so the order between them was not guaranteed
+				sort.Strings(got)
+				sort.Strings(tt.wantOutput)
+				if !reflect.DeepEqual(got, tt.wantOutput) {
+					t.Errorf("ExecWithStreamOutput() output = %v, want %v", got, tt.wantOutput)
+				}
+			}
+			if tt.wantMessages == nil {
+				if err != nil {
+					t.Errorf("ExecWithStreamOutput() error = %v", err)
+				}
+				return
+			}
+			var e *ExecError
+			if !errors.As(err, &e) {
+				t.Fatalf("ExecWithStreamOutput() error = %v, want the ExecError", err)
+			}
+			messages := e.Messages()
+			if len(messages) != 1+ExecStderrTail {
+				t.Errorf("ExecError.Messages() = %v, want %d lines", messages, 1+ExecStderrTail)
+			}
+			if messages[0] != tt.wantMessages[0] || messages[1] != tt.wantMessages[1] || messages[len(messages)-1] != tt.wantMessages[2] {
+				t.Errorf("ExecError.Messages() = %v, want %v", messages, tt.wantMessages)
+			}
+		})
+	}
+}
@@ -1,8 +1,14 @@
 package operators
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
@@ -23,6 +29,7 @@ func NewGit(gitDir string, branchName string) interfaces.StepOperator {
 			Policy:         types.StepPolicyAuto,
 			Available:      types.StepAvailableEnable,
 			Envs:           envs,
+			Messages:       make([]string, 0),
 			Output:         make([]string, 0),
 			SharingData:    make(map[string]string, 0),
 			SharingSetting: false,
@@ -30,9 +37,63 @@ func NewGit(gitDir string, branchName string) interfaces.StepOperator {
 	}
 }
 
+const (
+	GitCommandClone     = "clone"
+	GitCommandCD        = "cd"
+	GitCommandRevert    = "revert"
+	GitCommandFetch     = "fetch"
+	GitCommandCheckout  = "checkout"
+	GitCommandPull      = "pull"
+	GitCommandPush      = "push"
+	GitCommandSubmodule = "submodule update"
+	GitCommandLfs       = "lfs pull"
+	GitCommandLog       = "log"
+
+	ErrGitProjectDirNotExisted = "error: the git project dir:%s was not existed"
+	ErrGitNotRepository        = "error: the dir:%s was not a git work tree"
+	ErrGitBranchInvalid        = "error: the git branch name:%q was invalid"
+	ErrGitBranchNotExisted     = "error: the git branch:%s was not existed in the remote origin"
+	ErrGitDirtyTree            = "error: the git work tree:%s had local changes:\n%s"
+	ErrGitAuthFailed           = "error: the git authentication was failed: %s"
+	ErrGitUrlInvalid           = "error: the git url:%q was invalid"
+	ErrGitTagInvalid           = "error: the git tag name:%q was invalid"
+	ErrGitTagNotExisted        = "error: the git tag:%s was not existed"
+	ErrGitCommitInvalid        = "error: the git commit:%q should be a hexadecimal sha"
+	ErrGitCommitNotExisted     = "error: the git commit:%s was not existed"
+	ErrGitDepthInvalid         = "error: the git depth:%s should be a positive integer"
+	ErrGitLfsNotInstalled      = "error: git-lfs was not installed"
+	ErrGitLogInvalid           = "error: failed to parse the git log:%q"
+	gitDirtyTreeMaxLines       = 20
+
+	// gitLogFormat separates the fields by NUL, so that the subject could contain anything
+	gitLogFormat = "--format=%H%x00%h%x00%an <%ae>%x00%cd%x00%s"
+	gitLogDate   = "--date=format:%Y-%m-%d %H:%M:%S"
+	GitLogRemark = `
+Commit:     %s
+Author:     %s
+DateTime:   %s
+Subject:    %s
+`
+)
+
+var gitCommitPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+
+// gitAuthFailures were the stderr fragments of git and the remote helpers when the credentials were refused
+var gitAuthFailures = []string{
+	"authentication failed",
+	"could not read username",
+	"could not read password",
+	"terminal prompts disabled",
+	"permission denied (publickey",
+	"invalid username or password",
+	"http basic: access denied",
+}
+
 type git struct {
 	output chan<- string
 	step   *types.Step
+	// nothingToCommit skips the push when the committing mode found nothing to commit
+	nothingToCommit bool
 }
 
 func (g *git) Step() *types.Step {
@@ -44,88 +105,409 @@ func (g *git) Update(s *types.Step) {
 }
 
 func (g *git) Prepare() {
+	g.step.Messages = make([]string, 0)
+	g.step.Remarks = make([]string, 0)
+}
 
+func (g *git) AppendMessage(action string) {
+	g.step.Messages = append(g.step.Messages, types.StepMessage(g.step.Name, action))
 }
 
 func (g *git) Run(output chan<- string) (res []string, err error) {
 	g.output = output
 	g.step.Phase = types.StepRunning
-	var out []byte
-	if out, err = g.cd(); err != nil {
-		klog.V(2).Info(err)
-		g.step.Phase = types.StepFailed
-		return res, err
+	if g.step.SharingData == nil {
+		g.step.SharingData = make(map[string]string, 0)
 	}
-	if out, err = g.revert(); err != nil {
-		klog.V(2).Info(err)
-		g.step.Phase = types.StepFailed
-		return res, err
+	g.nothingToCommit = false
+	mode := g.step.Envs[types.PublisherGitCommand]
+	if mode == "" {
+		mode = GitCommandWaiting
 	}
-	if out, err = g.checkout(); err != nil {
+	commands, err := g.commands(mode)
+	if err != nil {
 		klog.V(2).Info(err)
 		g.step.Phase = types.StepFailed
 		return res, err
 	}
-	if out, err = g.pull(); err != nil {
-		klog.V(2).Info(err)
-		g.step.Phase = types.StepFailed
-		return res, err
+	g.AppendMessage(mode)
+	var out []byte
+	for _, v := range commands {
+		g.AppendMessage(v.action)
+		if out, err = v.f(); err != nil {
+			klog.V(2).Info(err)
+			g.step.Phase = types.StepFailed
+			return res, err
+		}
 	}
 	res = append(res, string(out))
 	g.step.Phase = types.StepSucceeded
 	return res, nil
 }
 
+type gitCommand struct {
+	action string
+	f      func() ([]byte, error)
+}
+
+// commands returns the commands of the Run by the PUBLISHER_GIT_COMMAND, the waiting mode was the default one.
+// In the waiting mode, the branch would be pulled only when neither the commit nor the tag was set.
+func (g *git) commands(mode string) ([]gitCommand, error) {
+	switch mode {
+	case GitCommandWaiting:
+	case GitCommandCommitting:
+		return g.committingCommands(), nil
+	default:
+		return nil, fmt.Errorf(ErrGitCommandUnknown, mode)
+	}
+	res := []gitCommand{
+		{GitCommandClone, g.clone},
+		{GitCommandCD, g.cd},
+		{GitCommandRevert, g.revert},
+		{GitCommandFetch, g.fetchAll},
+		{GitCommandCheckout, g.checkout},
+	}
+	if g.step.Envs[types.PublisherGitCommit] == "" && g.step.Envs[types.PublisherGitTag] == "" {
+		res = append(res, gitCommand{GitCommandPull, g.pull})
+	}
+	if envEnabled(g.step.Envs, types.PublisherGitSubmodules) {
+		res = append(res, gitCommand{GitCommandSubmodule, g.submodule})
+	}
+	if envEnabled(g.step.Envs, types.PublisherGitLfs) {
+		res = append(res, gitCommand{GitCommandLfs, g.lfs})
+	}
+	return append(res, gitCommand{GitCommandLog, g.log}), nil
+}
+
+// envEnabled returns whether the value of the key was a true boolean
+func envEnabled(envs map[string]string, key string) bool {
+	v, _ := strconv.ParseBool(envs[key])
+	return v
+}
+
+// exec runs git with the args in the project dir, the stdout would be streamed into the output.
+// The terminal prompts were disabled, so that the missing credentials would fail instead of hanging the runner.
+func (g *git) exec(args ...string) ([]byte, error) {
+	cmd := g.command(args...)
+	out, err := execCmdWithStreamOutput(cmd, g.output)
+	if err != nil {
+		return out, gitError(err)
+	}
+	return out, nil
+}
+
+// query runs git with the args in the project dir and returns the trimmed stdout without streaming it
+func (g *git) query(args ...string) (string, error) {
+	return g.stdout(g.command(args...))
+}
+
+// checkRefFormat runs the git check-ref-format out of the project dir, because it needs no repository
+// and the project dir may not have been cloned yet
+func (g *git) checkRefFormat(args ...string) error {
+	cmd := g.command(append([]string{"check-ref-format"}, args...)...)
+	cmd.Dir = ""
+	_, err := g.stdout(cmd)
+	return err
+}
+
+// stdout runs the cmd and returns the trimmed stdout, the stderr would be kept in the ExecError
+func (g *git) stdout(cmd *exec.Cmd) (string, error) {
+	out, err := cmd.Output()
+	if err != nil {
+		e := &ExecError{Args: cmd.Args, ExitCode: -1, Err: err}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			e.ExitCode = exitErr.ExitCode()
+			e.Stderr = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		return "", gitError(e)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (g *git) command(args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.step.Envs[types.PublisherProjectDir]
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	return cmd
+}
+
+// gitError converts the ExecError whose stderr reported the refused credentials into the authentication error
+func gitError(err error) error {
+	var e *ExecError
+	if !errors.As(err, &e) {
+		return err
+	}
+	stderr := strings.ToLower(e.Stderr)
+	for _, v := range gitAuthFailures {
+		if strings.Contains(stderr, v) {
+			return fmt.Errorf(ErrGitAuthFailed, e.Stderr)
+		}
+	}
+	return err
+}
+
+// cd validates that the project dir was existed and it was a git work tree
 func (g *git) cd() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s", g.step.Envs[types.PublisherProjectDir])
-	return DefaultExec(commands)
+	dir := g.step.Envs[types.PublisherProjectDir]
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return nil, fmt.Errorf(ErrGitProjectDirNotExisted, dir)
+	}
+	if out, err := g.query("rev-parse", "--is-inside-work-tree"); err != nil || out != "true" {
+		klog.V(2).Info(err)
+		return nil, fmt.Errorf(ErrGitNotRepository, dir)
+	}
+	return nil, nil
 }
 
+// branch returns the name of the current branch
 func (g *git) branch() (res string, err error) {
-	commands := fmt.Sprintf("cd %s && git branch -a | grep '*'", g.step.Envs[types.PublisherProjectDir])
-	t, err := DefaultExec(commands)
+	return g.query("rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// depth returns the depth of the shallow clone and fetch, zero means the full history
+func (g *git) depth() (int, error) {
+	v := strings.TrimSpace(g.step.Envs[types.PublisherGitDepth])
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf(ErrGitDepthInvalid, v)
+	}
+	return n, nil
+}
+
+// clone clones the PUBLISHER_GIT_URL into the project dir when the dir was missing or empty,
+// otherwise the existing clone would be used as before
+func (g *git) clone() (res []byte, err error) {
+	url := g.step.Envs[types.PublisherGitUrl]
+	if url == "" {
+		return nil, nil
+	}
+	dir, err := filepath.Abs(g.step.Envs[types.PublisherProjectDir])
 	if err != nil {
-		klog.V(2).Info(err)
-		return res, err
+		return nil, err
+	}
+	if entries, err := ioutil.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, err
 	}
-	activeMatched, err := regexp.Match(`\*`, t)
+	if strings.HasPrefix(url, "-") {
+		return nil, fmt.Errorf(ErrGitUrlInvalid, url)
+	}
+	depth, err := g.depth()
 	if err != nil {
-		return res, fmt.Errorf("git regexp.Match active target-name:%s err:%v\n", g.step.Envs[types.PublisherGitBranch], err)
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, err
 	}
-	if activeMatched == false {
-		return res, fmt.Errorf("git regexp.Match active target-name:%s failed", g.step.Envs[types.PublisherGitBranch])
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth), "--no-single-branch")
 	}
-	var name string
-	name = strings.Replace(string(t), " ", "", -1)
-	name = strings.Replace(name, "*", "", -1)
-	// such as `* test\n`
-	name = strings.Replace(name, "\n", "", -1)
-	return name, nil
+	args = append(args, "--", url, dir)
+	cmd := g.command(args...)
+	cmd.Dir = filepath.Dir(dir)
+	if res, err = execCmdWithStreamOutput(cmd, g.output); err != nil {
+		return res, gitError(err)
+	}
+	return res, nil
 }
 
 func (g *git) fetchAll() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && git fetch --all && git fetch -p", g.step.Envs[types.PublisherProjectDir])
-	return ExecWithStreamOutput(commands, g.output)
+	depth, err := g.depth()
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"fetch", "--all", "--prune", "--tags", "--force"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	return g.exec(args...)
 }
 
+// revert discards the local changes including the untracked files, unless the PUBLISHER_GIT_DISCARD_CHANGES
+// was `false`. The work tree would be checked again afterwards, so that a dirty one would never be published.
 func (g *git) revert() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && git add --all && git checkout -f && git reset --hard", g.step.Envs[types.PublisherProjectDir])
-	return ExecWithStreamOutput(commands, g.output)
+	discard := true
+	if v, ok := g.step.Envs[types.PublisherGitDiscardChanges]; ok && v != "" {
+		if discard, err = strconv.ParseBool(v); err != nil {
+			return nil, err
+		}
+	}
+	if discard {
+		for _, args := range [][]string{
+			{"add", "--all"},
+			{"checkout", "-f"},
+			{"reset", "--hard"},
+		} {
+			out, err := g.exec(args...)
+			res = append(res, out...)
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+	status, err := g.query("status", "--porcelain")
+	if err != nil {
+		return res, err
+	}
+	if status != "" {
+		lines := strings.Split(status, "\n")
+		if len(lines) > gitDirtyTreeMaxLines {
+			lines = append(lines[:gitDirtyTreeMaxLines], fmt.Sprintf("... %d more", len(lines)-gitDirtyTreeMaxLines))
+		}
+		return res, fmt.Errorf(ErrGitDirtyTree, g.step.Envs[types.PublisherProjectDir], strings.Join(lines, "\n"))
+	}
+	return res, nil
+}
+
+// validateBranch rejects the names which would be parsed as options or which were not valid branch names
+func (g *git) validateBranch(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return fmt.Errorf(ErrGitBranchInvalid, name)
+	}
+	if err := g.checkRefFormat("--branch", name); err != nil {
+		klog.V(2).Info(err)
+		return fmt.Errorf(ErrGitBranchInvalid, name)
+	}
+	return nil
 }
 
+// checkout checks out the commit, the tag or the branch in order, the former two would detach the HEAD
 func (g *git) checkout() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && git checkout -B %s --track remotes/origin/%s",
-		g.step.Envs[types.PublisherProjectDir], g.step.Envs[types.PublisherGitBranch], g.step.Envs[types.PublisherGitBranch])
-	klog.Info("git checkout commands:", commands)
-	return ExecWithStreamOutput(commands, g.output)
+	if commit := g.step.Envs[types.PublisherGitCommit]; commit != "" {
+		return g.checkoutCommit(commit)
+	}
+	if tag := g.step.Envs[types.PublisherGitTag]; tag != "" {
+		return g.checkoutTag(tag)
+	}
+	name := g.step.Envs[types.PublisherGitBranch]
+	if err = g.validateBranch(name); err != nil {
+		return nil, err
+	}
+	remote := "refs/remotes/origin/" + name
+	if _, err = g.query("rev-parse", "--verify", "--quiet", remote); err != nil {
+		klog.V(2).Info(err)
+		return nil, fmt.Errorf(ErrGitBranchNotExisted, name)
+	}
+	klog.Infof("git checkout branch:%s", name)
+	return g.exec("checkout", "-B", name, "--track", "origin/"+name)
+}
+
+func (g *git) checkoutTag(tag string) (res []byte, err error) {
+	ref := "refs/tags/" + tag
+	if strings.HasPrefix(tag, "-") {
+		return nil, fmt.Errorf(ErrGitTagInvalid, tag)
+	}
+	if err = g.checkRefFormat(ref); err != nil {
+		klog.V(2).Info(err)
+		return nil, fmt.Errorf(ErrGitTagInvalid, tag)
+	}
+	if _, err = g.query("rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		klog.V(2).Info(err)
+		return nil, fmt.Errorf(ErrGitTagNotExisted, tag)
+	}
+	klog.Infof("git checkout tag:%s", tag)
+	return g.exec("checkout", "--detach", ref+"^{commit}")
+}
+
+// checkoutCommit checks out the exact commit, it would be fetched individually if the shallow history did not contain it
+func (g *git) checkoutCommit(commit string) (res []byte, err error) {
+	if !gitCommitPattern.MatchString(commit) {
+		return nil, fmt.Errorf(ErrGitCommitInvalid, commit)
+	}
+	if _, err = g.query("rev-parse", "--verify", "--quiet", commit+"^{commit}"); err != nil {
+		depth, err := g.depth()
+		if err != nil {
+			return nil, err
+		}
+		args := []string{"fetch"}
+		if depth > 0 {
+			args = append(args, "--depth", strconv.Itoa(depth))
+		}
+		if res, err = g.exec(append(args, "origin", commit)...); err != nil {
+			klog.V(2).Info(err)
+		}
+		if _, err = g.query("rev-parse", "--verify", "--quiet", commit+"^{commit}"); err != nil {
+			klog.V(2).Info(err)
+			return res, fmt.Errorf(ErrGitCommitNotExisted, commit)
+		}
+	}
+	klog.Infof("git checkout commit:%s", commit)
+	return g.exec("checkout", "--detach", commit+"^{commit}")
+}
+
+func (g *git) submodule() (res []byte, err error) {
+	if res, err = g.exec("submodule", "sync", "--recursive"); err != nil {
+		return res, err
+	}
+	args := []string{"submodule", "update", "--init", "--recursive", "--force"}
+	depth, err := g.depth()
+	if err != nil {
+		return res, err
+	}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	out, err := g.exec(args...)
+	return append(res, out...), err
+}
+
+func (g *git) lfs() (res []byte, err error) {
+	if _, err = exec.LookPath("git-lfs"); err != nil {
+		klog.V(2).Info(err)
+		return nil, errors.New(ErrGitLfsNotInstalled)
+	}
+	if res, err = g.exec("lfs", "install", "--local"); err != nil {
+		return res, err
+	}
+	out, err := g.exec("lfs", "pull")
+	return append(res, out...), err
+}
+
+// log records the checked out commit into the Remarks and the SharingData
+func (g *git) log() (res []byte, err error) {
+	out, err := g.query("log", "-1", gitLogDate, gitLogFormat)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.SplitN(out, "\x00", 5)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf(ErrGitLogInvalid, out)
+	}
+	g.step.SharingData[types.PublisherGitSha] = fields[0]
+	g.step.SharingData[types.PublisherGitShortSha] = fields[1]
+	g.step.SharingData[types.PublisherGitAuthor] = fields[2]
+	g.step.SharingData[types.PublisherGitSubject] = fields[4]
+	g.step.Remarks = append(g.step.Remarks, fmt.Sprintf(GitLogRemark, fields[0], fields[2], fields[3], fields[4]))
+	return []byte(out), nil
 }
 
 func (g *git) pull() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && git pull", g.step.Envs[types.PublisherProjectDir])
-	return ExecWithStreamOutput(commands, g.output)
+	return g.exec("pull")
 }
 
+// push pushes the HEAD onto the branch of the origin, and the tag which was created by the commit
 func (g *git) push() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && git push", g.step.Envs[types.PublisherProjectDir])
-	return ExecWithStreamOutput(commands, g.output)
+	if g.nothingToCommit {
+		return nil, nil
+	}
+	name := g.step.Envs[types.PublisherGitBranch]
+	if name == "" {
+		if name, err = g.branch(); err != nil {
+			return nil, err
+		}
+	}
+	if err = g.validateBranch(name); err != nil {
+		return nil, err
+	}
+	args := []string{"push", "origin", "HEAD:refs/heads/" + name}
+	if tag := g.step.SharingData[types.PublisherGitPushedTag]; tag != "" {
+		args = append(args, "refs/tags/"+tag)
+	}
+	return g.exec(args...)
 }
@@ -0,0 +1,111 @@
+package operators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	GitCommandWaiting    = "pulling and waiting"
+	GitCommandCommitting = "adding and committing"
+	GitCommandAddAll     = "add all"
+	GitCommandCommit     = "commit"
+	GitCommandTag        = "tag"
+
+	DefaultGitCommitMessage = "Automate Runner{{if .Version}} {{.Version}}{{end}} - committed by {{.User}}@publisher"
+
+	ErrGitCommandUnknown = "error: unknown git command:%s"
+	ErrGitMessageEmpty   = "error: the git commit message template:%s rendered an empty message"
+	gitNothingToCommit   = "nothing to commit, the work tree was clean"
+)
+
+// renderGitTemplate renders the text with the data of the Step
+func renderGitTemplate(step *types.Step, text string) (string, error) {
+	res, err := renderStepTemplate(text, newStepTemplateData(step))
+	return strings.TrimSpace(res), err
+}
+
+// committingCommands returns the commands of the committing mode, the current branch would not be switched,
+// so that the exported files would be committed onto the branch which was checked out by the waiting mode
+func (g *git) committingCommands() []gitCommand {
+	return []gitCommand{
+		{GitCommandCD, g.cd},
+		{GitCommandAddAll, g.addAll},
+		{GitCommandCommit, g.commit},
+		{GitCommandPush, g.push},
+		{GitCommandLog, g.log},
+	}
+}
+
+func (g *git) addAll() (res []byte, err error) {
+	return g.exec("add", "--all")
+}
+
+// commit commits the staged changes with the templated message, and creates the annotated tag if it was set.
+// The push would be skipped if there was nothing to commit.
+func (g *git) commit() (res []byte, err error) {
+	delete(g.step.SharingData, types.PublisherGitPushedTag)
+	status, err := g.query("status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if status == "" {
+		g.nothingToCommit = true
+		g.step.Remarks = append(g.step.Remarks, gitNothingToCommit)
+		g.output <- gitNothingToCommit
+		return nil, nil
+	}
+	text := g.step.Envs[types.PublisherGitCommitMessage]
+	if text == "" {
+		text = DefaultGitCommitMessage
+	}
+	message, err := renderGitTemplate(g.step, text)
+	if err != nil {
+		return nil, err
+	}
+	if message == "" {
+		return nil, fmt.Errorf(ErrGitMessageEmpty, text)
+	}
+	if res, err = g.exec(g.identity("commit", "--message", message)...); err != nil {
+		return res, err
+	}
+	text = g.step.Envs[types.PublisherGitPushTag]
+	if text == "" {
+		return res, nil
+	}
+	tag, err := renderGitTemplate(g.step, text)
+	if err != nil {
+		return res, err
+	}
+	if tag == "" || strings.HasPrefix(tag, "-") {
+		return res, fmt.Errorf(ErrGitTagInvalid, tag)
+	}
+	if err = g.checkRefFormat("refs/tags/" + tag); err != nil {
+		klog.V(2).Info(err)
+		return res, fmt.Errorf(ErrGitTagInvalid, tag)
+	}
+	g.AppendMessage(GitCommandTag)
+	out, err := g.exec(g.identity("tag", "--annotate", "--message", message, tag)...)
+	res = append(res, out...)
+	if err != nil {
+		return res, err
+	}
+	g.step.SharingData[types.PublisherGitPushedTag] = tag
+	g.step.Remarks = append(g.step.Remarks, fmt.Sprintf("git tag: %s", tag))
+	return res, nil
+}
+
+// identity prepends the committer of the Envs to the args
+func (g *git) identity(args ...string) []string {
+	res := make([]string, 0, len(args)+4)
+	if v := g.step.Envs[types.PublisherGitUserName]; v != "" {
+		res = append(res, "-c", "user.name="+v)
+	}
+	if v := g.step.Envs[types.PublisherGitUserEmail]; v != "" {
+		res = append(res, "-c", "user.email="+v)
+	}
+	return append(res, args...)
+}
@@ -2,7 +2,14 @@ package operators
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
@@ -356,3 +363,434 @@ func Test_git_branch(t *testing.T) {
 		})
 	}
 }
+
+// runGit runs git in the dir for preparing the fixtures
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=publisher", "-c", "user.email=publisher@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v err:%v output:%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newGitFixture creates a bare origin with the branches main and test and the tag v1.0.0 on main, and a clone of it
+func newGitFixture(t *testing.T) (origin, clone string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git was not installed")
+	}
+	root := t.TempDir()
+	origin = filepath.Join(root, "origin.git")
+	seed := filepath.Join(root, "seed")
+	clone = filepath.Join(root, "clone")
+	runGit(t, root, "init", "--bare", origin)
+	runGit(t, origin, "symbolic-ref", "HEAD", "refs/heads/main")
+	runGit(t, root, "init", seed)
+	runGit(t, seed, "checkout", "-b", "main")
+	if err := ioutil.WriteFile(filepath.Join(seed, "README.md"), []byte("main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, seed, "add", "--all")
+	runGit(t, seed, "commit", "-m", "init")
+	runGit(t, seed, "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	runGit(t, seed, "checkout", "-b", "test")
+	if err := ioutil.WriteFile(filepath.Join(seed, "README.md"), []byte("test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, seed, "commit", "-am", "test")
+	runGit(t, seed, "remote", "add", "origin", origin)
+	runGit(t, seed, "push", "origin", "main", "test", "v1.0.0")
+	runGit(t, root, "clone", "--branch", "main", origin, clone)
+	return origin, clone
+}
+
+func Test_git_Run_local(t *testing.T) {
+	_, clone := newGitFixture(t)
+	pwned := filepath.Join(t.TempDir(), "pwned")
+	tests := []struct {
+		name    string
+		dir     string
+		branch  string
+		envs    map[string]string
+		prepare func(t *testing.T)
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "Test_git_Run_local_1",
+			dir:    clone,
+			branch: "test",
+			want:   "test\n",
+		},
+		{
+			name:   "Test_git_Run_local_2",
+			dir:    clone,
+			branch: "main",
+			prepare: func(t *testing.T) {
+				_ = ioutil.WriteFile(filepath.Join(clone, "README.md"), []byte("dirty\n"), 0644)
+				_ = ioutil.WriteFile(filepath.Join(clone, "untracked"), []byte("dirty\n"), 0644)
+			},
+			want: "main\n",
+		},
+		{
+			name:    "Test_git_Run_local_3",
+			dir:     filepath.Join(clone, "missing"),
+			branch:  "main",
+			wantErr: "was not existed",
+		},
+		{
+			name:    "Test_git_Run_local_4",
+			dir:     t.TempDir(),
+			branch:  "main",
+			wantErr: "was not a git work tree",
+		},
+		{
+			name:    "Test_git_Run_local_5",
+			dir:     clone,
+			branch:  "main; touch " + pwned,
+			wantErr: "was invalid",
+		},
+		{
+			name:    "Test_git_Run_local_6",
+			dir:     clone,
+			branch:  "--upload-pack=touch " + pwned,
+			wantErr: "was invalid",
+		},
+		{
+			name:    "Test_git_Run_local_7",
+			dir:     clone,
+			branch:  "release",
+			wantErr: "was not existed in the remote origin",
+		},
+		{
+			name:   "Test_git_Run_local_8",
+			dir:    clone,
+			branch: "main",
+			envs:   map[string]string{types.PublisherGitDiscardChanges: "false"},
+			prepare: func(t *testing.T) {
+				_ = ioutil.WriteFile(filepath.Join(clone, "README.md"), []byte("dirty\n"), 0644)
+			},
+			wantErr: "had local changes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prepare != nil {
+				tt.prepare(t)
+			}
+			g := NewGit(tt.dir, tt.branch).(*git)
+			for k, v := range tt.envs {
+				g.step.Envs[k] = v
+			}
+			_, err := g.Run(make(chan string, 4096))
+			if _, statErr := os.Stat(pwned); statErr == nil {
+				t.Fatalf("git.Run() executed the injected command")
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("git.Run() error = %v, wantErr %s", err, tt.wantErr)
+				}
+				if g.step.Phase != types.StepFailed {
+					t.Errorf("git.Run() phase = %s, want %s", g.step.Phase, types.StepFailed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("git.Run() error = %v", err)
+			}
+			if got, _ := g.branch(); got != tt.branch {
+				t.Errorf("git.branch() = %v, want %v", got, tt.branch)
+			}
+			content, err := ioutil.ReadFile(filepath.Join(tt.dir, "README.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("README.md = %q, want %q", content, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(tt.dir, "untracked")); err == nil {
+				t.Errorf("the untracked file was not discarded")
+			}
+		})
+	}
+}
+
+func Test_git_fetchAll_auth(t *testing.T) {
+	_, clone := newGitFixture(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="publisher"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	runGit(t, clone, "remote", "set-url", "origin", server.URL+"/origin.git")
+	g := NewGit(clone, "main").(*git)
+	g.output = make(chan string, 4096)
+	_, err := g.fetchAll()
+	if err == nil || !strings.HasPrefix(err.Error(), "error: the git authentication was failed") {
+		t.Fatalf("git.fetchAll() error = %v, want the authentication error", err)
+	}
+}
+
+func Test_git_Run_clone(t *testing.T) {
+	origin, clone := newGitFixture(t)
+	mainSha := runGit(t, clone, "rev-parse", "origin/main")
+	testSha := runGit(t, clone, "rev-parse", "origin/test")
+	// the submodule was added on the branch sub, and the file protocol was disallowed for the submodules by default
+	runGit(t, clone, "checkout", "-b", "sub")
+	runGit(t, clone, "-c", "protocol.file.allow=always", "submodule", "add", origin, "modules/origin")
+	runGit(t, clone, "commit", "-m", "add the submodule")
+	runGit(t, clone, "push", "origin", "sub")
+	for k, v := range map[string]string{
+		"GIT_CONFIG_COUNT":   "1",
+		"GIT_CONFIG_KEY_0":   "protocol.file.allow",
+		"GIT_CONFIG_VALUE_0": "always",
+	} {
+		old, ok := os.LookupEnv(k)
+		_ = os.Setenv(k, v)
+		defer func(k, old string, ok bool) {
+			if ok {
+				_ = os.Setenv(k, old)
+			} else {
+				_ = os.Unsetenv(k)
+			}
+		}(k, old, ok)
+	}
+	tests := []struct {
+		name    string
+		branch  string
+		envs    map[string]string
+		want    string
+		wantSha string
+		wantErr string
+	}{
+		{
+			name:    "Test_git_Run_clone_1",
+			branch:  "test",
+			envs:    map[string]string{types.PublisherGitDepth: "1"},
+			want:    "test\n",
+			wantSha: testSha,
+		},
+		{
+			name:    "Test_git_Run_clone_2",
+			branch:  "test",
+			envs:    map[string]string{types.PublisherGitTag: "v1.0.0"},
+			want:    "main\n",
+			wantSha: mainSha,
+		},
+		{
+			name:    "Test_git_Run_clone_3",
+			branch:  "test",
+			envs:    map[string]string{types.PublisherGitCommit: mainSha[:10], types.PublisherGitDepth: "1"},
+			want:    "main\n",
+			wantSha: mainSha,
+		},
+		{
+			name:    "Test_git_Run_clone_4",
+			branch:  "test",
+			envs:    map[string]string{types.PublisherGitTag: "v2.0.0"},
+			wantErr: "was not existed",
+		},
+		{
+			name:    "Test_git_Run_clone_5",
+			branch:  "test",
+			envs:    map[string]string{types.PublisherGitCommit: "HEAD; rm -rf /"},
+			wantErr: "should be a hexadecimal sha",
+		},
+		{
+			name:    "Test_git_Run_clone_6",
+			branch:  "test",
+			envs:    map[string]string{types.PublisherGitDepth: "-1"},
+			wantErr: "should be a positive integer",
+		},
+		{
+			name:    "Test_git_Run_clone_7",
+			branch:  "sub",
+			envs:    map[string]string{types.PublisherGitSubmodules: "true"},
+			want:    "main\n",
+			wantSha: runGit(t, clone, "rev-parse", "HEAD"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "project")
+			g := NewGit(dir, tt.branch).(*git)
+			g.step.Envs[types.PublisherGitUrl] = origin
+			for k, v := range tt.envs {
+				g.step.Envs[k] = v
+			}
+			_, err := g.Run(make(chan string, 4096))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("git.Run() error = %v, wantErr %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("git.Run() error = %v", err)
+			}
+			if got := g.step.SharingData[types.PublisherGitSha]; got != tt.wantSha {
+				t.Errorf("SharingData[%s] = %v, want %v", types.PublisherGitSha, got, tt.wantSha)
+			}
+			if got := g.step.SharingData[types.PublisherGitShortSha]; !strings.HasPrefix(tt.wantSha, got) || got == "" {
+				t.Errorf("SharingData[%s] = %v, want the prefix of %v", types.PublisherGitShortSha, got, tt.wantSha)
+			}
+			if got := g.step.SharingData[types.PublisherGitAuthor]; got != "publisher <publisher@example.com>" {
+				t.Errorf("SharingData[%s] = %v", types.PublisherGitAuthor, got)
+			}
+			if len(g.step.Remarks) != 1 || !strings.Contains(g.step.Remarks[0], tt.wantSha) {
+				t.Errorf("Remarks = %v, want the commit %s", g.step.Remarks, tt.wantSha)
+			}
+			root := dir
+			if envEnabled(tt.envs, types.PublisherGitSubmodules) {
+				root = filepath.Join(dir, "modules", "origin")
+			}
+			content, err := ioutil.ReadFile(filepath.Join(root, "README.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("README.md = %q, want %q", content, tt.want)
+			}
+			if tt.envs[types.PublisherGitDepth] == "1" {
+				if got := runGit(t, dir, "rev-list", "--count", "HEAD"); got != "1" {
+					t.Errorf("the history of the shallow clone = %s, want 1", got)
+				}
+			}
+			// the second Run reuses the existing clone
+			if _, err = g.Run(make(chan string, 4096)); err != nil {
+				t.Errorf("git.Run() again error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_git_validateBranch(t *testing.T) {
+	// the project dir was not cloned yet
+	g := NewGit(filepath.Join(t.TempDir(), "project"), "main").(*git)
+	tests := []struct {
+		name    string
+		branch  string
+		wantErr bool
+	}{
+		{name: "Test_git_validateBranch_1", branch: "main", wantErr: false},
+		{name: "Test_git_validateBranch_2", branch: "feature/login", wantErr: false},
+		{name: "Test_git_validateBranch_3", branch: "--upload-pack=touch", wantErr: true},
+		{name: "Test_git_validateBranch_4", branch: "a..b", wantErr: true},
+		{name: "Test_git_validateBranch_5", branch: "main; rm -rf /", wantErr: true},
+		{name: "Test_git_validateBranch_6", branch: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := g.validateBranch(tt.branch); (err != nil) != tt.wantErr {
+				t.Errorf("git.validateBranch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_git_lfs(t *testing.T) {
+	if _, err := exec.LookPath("git-lfs"); err == nil {
+		t.Skip("git-lfs was installed")
+	}
+	_, clone := newGitFixture(t)
+	g := NewGit(clone, "main").(*git)
+	g.step.Envs[types.PublisherGitLfs] = "true"
+	if _, err := g.Run(make(chan string, 4096)); err == nil || err.Error() != ErrGitLfsNotInstalled {
+		t.Fatalf("git.Run() error = %v, want %s", err, ErrGitLfsNotInstalled)
+	}
+}
+
+func Test_git_Run_committing(t *testing.T) {
+	origin, clone := newGitFixture(t)
+	tests := []struct {
+		name        string
+		envs        map[string]string
+		prepare     func(t *testing.T)
+		wantMessage string
+		wantTag     string
+		wantErr     string
+	}{
+		{
+			name: "Test_git_Run_committing_1",
+			envs: map[string]string{
+				types.PublisherTriggerUser: "alice",
+				types.PublisherVersion:     "1.2.3",
+			},
+			prepare: func(t *testing.T) {
+				_ = ioutil.WriteFile(filepath.Join(clone, "config.json"), []byte("{}\n"), 0644)
+			},
+			wantMessage: "Automate Runner 1.2.3 - committed by alice@publisher",
+		},
+		{
+			name: "Test_git_Run_committing_2",
+			envs: map[string]string{
+				types.PublisherVersion:          "1.2.4",
+				types.PublisherGitCommitMessage: "export the config tables {{.Version}} by {{.User}}",
+				types.PublisherGitPushTag:       "config-{{.Version}}",
+			},
+			prepare: func(t *testing.T) {
+				_ = ioutil.WriteFile(filepath.Join(clone, "config.json"), []byte("{\"a\":1}\n"), 0644)
+			},
+			wantMessage: "export the config tables 1.2.4 by publisher",
+			wantTag:     "config-1.2.4",
+		},
+		{
+			name: "Test_git_Run_committing_3",
+			envs: map[string]string{
+				types.PublisherVersion: "1.2.5",
+			},
+			wantMessage: "export the config tables 1.2.4 by publisher",
+		},
+		{
+			name: "Test_git_Run_committing_4",
+			envs: map[string]string{
+				types.PublisherGitPushTag: "bad tag",
+			},
+			prepare: func(t *testing.T) {
+				_ = ioutil.WriteFile(filepath.Join(clone, "config.json"), []byte("{\"a\":2}\n"), 0644)
+			},
+			wantErr: "was invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prepare != nil {
+				tt.prepare(t)
+			}
+			g := NewGit(clone, "main").(*git)
+			g.step.Envs[types.PublisherGitCommand] = GitCommandCommitting
+			g.step.Envs[types.PublisherGitUserName] = "publisher"
+			g.step.Envs[types.PublisherGitUserEmail] = "publisher@example.com"
+			for k, v := range tt.envs {
+				g.step.Envs[k] = v
+			}
+			_, err := g.Run(make(chan string, 4096))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("git.Run() error = %v, wantErr %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("git.Run() error = %v", err)
+			}
+			if got := runGit(t, origin, "log", "-1", "--format=%s", "main"); got != tt.wantMessage {
+				t.Errorf("the subject of origin/main = %q, want %q", got, tt.wantMessage)
+			}
+			if got := g.step.SharingData[types.PublisherGitSubject]; got != tt.wantMessage {
+				t.Errorf("SharingData[%s] = %q, want %q", types.PublisherGitSubject, got, tt.wantMessage)
+			}
+			if tt.wantTag != "" {
+				if got := runGit(t, origin, "tag", "--list", tt.wantTag); got != tt.wantTag {
+					t.Errorf("the tags of origin = %q, want %q", got, tt.wantTag)
+				}
+				if got := g.step.SharingData[types.PublisherGitPushedTag]; got != tt.wantTag {
+					t.Errorf("SharingData[%s] = %q, want %q", types.PublisherGitPushedTag, got, tt.wantTag)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package operators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// NewHttp returns the operator which sends the request to the url, such as reloading the config of the game servers
+// or purging the CDN cache after publishing. The name should be unique in the Runner.
+func NewHttp(name, method, url string) interfaces.StepOperator {
+	envs := make(map[string]string, 0)
+	envs[types.PublisherHttpMethod] = method
+	envs[types.PublisherHttpUrl] = url
+	return &httpHook{
+		step: &types.Step{
+			Id:             0,
+			Name:           name,
+			Phase:          types.StepPending,
+			Policy:         types.StepPolicyAuto,
+			Available:      types.StepAvailableEnable,
+			Envs:           envs,
+			Output:         make([]string, 0),
+			SharingData:    make(map[string]string, 0),
+			SharingSetting: false,
+		},
+		client: &http.Client{},
+	}
+}
+
+const (
+	ErrHttpUrlEmpty          = "error: the http_url was empty"
+	ErrHttpEnvInvalid        = "error: the %s:%s was invalid"
+	ErrHttpUnexpectedStatus  = "error: http %s %s responded the unexpected status:%d, expected:%s"
+	ErrHttpExtractInvalid    = "error: the http_extract:%q should be `KEY=path`"
+	ErrHttpExtractNotJson    = "error: the http response was not json: %v"
+	ErrHttpExtractNotFound   = "error: the http response had no field at the path:%s"
+	ErrHttpCancelled         = "error: http %s %s was cancelled"
+	httpDefaultMethod        = http.MethodGet
+	httpDefaultStatus        = "200-299"
+	httpDefaultTimeout       = 30 * time.Second
+	httpDefaultRetryInterval = time.Second
+	// httpMaxResponseBytes limits the response body which would be read for the output and the extraction
+	httpMaxResponseBytes = 1 << 20
+	// httpMaxOutputBytes limits the response body which would be streamed into the output
+	httpMaxOutputBytes  = 4096
+	httpSummaryTemplate = "http %s %s: %d in %s"
+	httpNoRetryOutput   = "http %s was not retried, because it may have reached the server, set the http_retry_non_idempotent to retry it"
+)
+
+// httpIdempotentMethods were the methods which would be retried by default, as the net/http Transport did
+var httpIdempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// httpHook implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator and Canceler
+type httpHook struct {
+	step   *types.Step
+	client *http.Client
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// httpRequest was the rendered request which could be sent repeatedly
+type httpRequest struct {
+	method  string
+	url     string
+	headers http.Header
+	body    []byte
+}
+
+func (h *httpHook) Step() *types.Step {
+	return h.step
+}
+
+func (h *httpHook) Update(s *types.Step) {
+	h.step = s.DeepCopy()
+}
+
+func (h *httpHook) Prepare() {
+	h.step.Remarks = make([]string, 0)
+}
+
+// Cancel aborts the request and the retries, it does nothing if the request was not being sent
+func (h *httpHook) Cancel() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cancel != nil {
+		h.cancel()
+	}
+}
+
+func (h *httpHook) Run(output chan<- string) (res []string, err error) {
+	h.step.Phase = types.StepRunning
+	if h.step.SharingData == nil {
+		h.step.SharingData = make(map[string]string, 0)
+	}
+	delete(h.step.SharingData, types.PublisherHttpStatus)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h.mu.Lock()
+	h.cancel = cancel
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		h.cancel = nil
+		h.mu.Unlock()
+	}()
+	if err = h.run(ctx, output); err != nil {
+		klog.V(2).Info(err)
+		h.step.Phase = types.StepFailed
+		return res, err
+	}
+	h.step.Phase = types.StepSucceeded
+	return res, nil
+}
+
+func (h *httpHook) run(ctx context.Context, output chan<- string) error {
+	req, err := h.request()
+	if err != nil {
+		return err
+	}
+	expected := h.step.Envs[types.PublisherHttpExpectedStatus]
+	if expected == "" {
+		expected = httpDefaultStatus
+	}
+	if _, err = matchHttpStatus(expected, 0); err != nil {
+		return err
+	}
+	retries, err := h.integer(types.PublisherHttpRetries, 0)
+	if err != nil {
+		return err
+	}
+	interval, err := h.integer(types.PublisherHttpRetryIntervalInMs, int(httpDefaultRetryInterval/time.Millisecond))
+	if err != nil {
+		return err
+	}
+	timeout, err := h.integer(types.PublisherHttpTimeout, int(httpDefaultTimeout/time.Second))
+	if err != nil {
+		return err
+	}
+	target := redactHttpUrl(req.url)
+	if retries > 0 && !httpIdempotentMethods[req.method] && !envEnabled(h.step.Envs, types.PublisherHttpRetryNonIdempotent) {
+		output <- fmt.Sprintf(httpNoRetryOutput, req.method)
+		retries = 0
+	}
+	for attempt := 0; ; attempt++ {
+		output <- fmt.Sprintf("http %s %s", req.method, target)
+		start := time.Now()
+		status, body, err := h.send(ctx, req, time.Duration(timeout)*time.Second)
+		if ctx.Err() != nil {
+			return fmt.Errorf(ErrHttpCancelled, req.method, target)
+		}
+		retryable := true
+		if err == nil {
+			h.step.SharingData[types.PublisherHttpStatus] = strconv.Itoa(status)
+			output <- fmt.Sprintf("http responded %d %s", status, http.StatusText(status))
+			if len(body) > 0 {
+				output <- truncateHttpBody(body)
+			}
+			if ok, _ := matchHttpStatus(expected, status); ok {
+				if err = h.extract(body); err != nil {
+					return err
+				}
+				h.step.Remarks = append(h.step.Remarks,
+					fmt.Sprintf(httpSummaryTemplate, req.method, target, status, time.Since(start).Round(time.Millisecond)))
+				return nil
+			}
+			err = fmt.Errorf(ErrHttpUnexpectedStatus, req.method, target, status, expected)
+			retryable = status >= http.StatusInternalServerError || status == http.StatusTooManyRequests
+		}
+		if !retryable || attempt >= retries {
+			return err
+		}
+		output <- fmt.Sprintf("http attempt %d/%d failed: %v, retrying in %dms", attempt+1, retries+1, err, interval)
+		select {
+		case <-time.After(time.Duration(interval) * time.Millisecond):
+		case <-ctx.Done():
+			return fmt.Errorf(ErrHttpCancelled, req.method, target)
+		}
+	}
+}
+
+// request renders the url, the headers and the body of the Envs
+func (h *httpHook) request() (*httpRequest, error) {
+	data := newStepTemplateData(h.step)
+	method := strings.ToUpper(strings.TrimSpace(h.step.Envs[types.PublisherHttpMethod]))
+	if method == "" {
+		method = httpDefaultMethod
+	}
+	rawUrl, err := renderStepTemplate(h.step.Envs[types.PublisherHttpUrl], data)
+	if err != nil {
+		return nil, err
+	}
+	rawUrl = strings.TrimSpace(rawUrl)
+	if rawUrl == "" {
+		return nil, errors.New(ErrHttpUrlEmpty)
+	}
+	if u, err := url.Parse(rawUrl); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf(ErrHttpEnvInvalid, types.PublisherHttpUrl, redactHttpUrl(rawUrl))
+	}
+	headers := make(http.Header)
+	for _, line := range strings.Split(h.step.Envs[types.PublisherHttpHeaders], "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		kv := strings.SplitN(line, ":", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, fmt.Errorf(ErrHttpEnvInvalid, types.PublisherHttpHeaders, key)
+		}
+		value, err := renderStepTemplate(strings.TrimSpace(kv[1]), data)
+		if err != nil {
+			return nil, err
+		}
+		headers.Add(key, value)
+	}
+	body, err := renderStepTemplate(h.step.Envs[types.PublisherHttpBody], data)
+	if err != nil {
+		return nil, err
+	}
+	return &httpRequest{method: method, url: rawUrl, headers: headers, body: []byte(body)}, nil
+}
+
+// send sends the request once and returns the status and the limited body of the response
+func (h *httpHook) send(ctx context.Context, r *httpRequest, timeout time.Duration) (int, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var body io.Reader
+	if len(r.body) > 0 {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	for k, v := range r.headers {
+		req.Header[k] = v
+	}
+	if len(r.body) > 0 && req.Header.Get("Content-Type") == "" {
+		if json.Valid(r.body) {
+			req.Header.Set("Content-Type", "application/json")
+		} else {
+			req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		}
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	res, err := ioutil.ReadAll(io.LimitReader(resp.Body, httpMaxResponseBytes))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, res, nil
+}
+
+// extract puts the fields of the json response into the SharingData by the http_extract
+func (h *httpHook) extract(body []byte) error {
+	pairs := strings.FieldsFunc(h.step.Envs[types.PublisherHttpExtract], func(r rune) bool { return r == ',' || r == '\n' })
+	if len(pairs) == 0 {
+		return nil
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf(ErrHttpExtractNotJson, err)
+	}
+	for _, v := range pairs {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		kv := strings.SplitN(v, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" || strings.TrimSpace(kv[1]) == "" {
+			return fmt.Errorf(ErrHttpExtractInvalid, v)
+		}
+		value, err := lookupJsonPath(doc, strings.TrimSpace(kv[1]))
+		if err != nil {
+			return err
+		}
+		h.step.SharingData[key] = value
+	}
+	return nil
+}
+
+// integer returns the non-negative integer of the key, or the default one if it was empty
+func (h *httpHook) integer(key string, def int) (int, error) {
+	v := strings.TrimSpace(h.step.Envs[key])
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf(ErrHttpEnvInvalid, key, v)
+	}
+	return n, nil
+}
+
+// matchHttpStatus reports whether the status was matched by the comma separated codes or ranges, such as `200-299,304`
+func matchHttpStatus(expected string, status int) (bool, error) {
+	matched := false
+	for _, v := range strings.Split(expected, ",") {
+		v = strings.TrimSpace(v)
+		bounds := strings.SplitN(v, "-", 2)
+		low, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return false, fmt.Errorf(ErrHttpEnvInvalid, types.PublisherHttpExpectedStatus, expected)
+		}
+		high := low
+		if len(bounds) == 2 {
+			if high, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil || high < low {
+				return false, fmt.Errorf(ErrHttpEnvInvalid, types.PublisherHttpExpectedStatus, expected)
+			}
+		}
+		if status >= low && status <= high {
+			matched = true
+		}
+	}
+	return matched, nil
+}
+
+// lookupJsonPath returns the field at the dot separated path, the array elements were referenced by their indexes.
+// The strings and the numbers were returned as they were, and the other values were returned as json.
+func lookupJsonPath(doc interface{}, p string) (string, error) {
+	cur := doc
+	for _, seg := range strings.Split(p, ".") {
+		switch v := cur.(type) {
+		case map[string]interface{}:
+			next, ok := v[seg]
+			if !ok {
+				return "", fmt.Errorf(ErrHttpExtractNotFound, p)
+			}
+			cur = next
+		case []interface{}:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", fmt.Errorf(ErrHttpExtractNotFound, p)
+			}
+			cur = v[i]
+		default:
+			return "", fmt.Errorf(ErrHttpExtractNotFound, p)
+		}
+	}
+	switch v := cur.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	b, err := json.Marshal(cur)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// redactHttpUrl hides the password of the url in the output
+func redactHttpUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return rawUrl
+	}
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+		}
+	}
+	return u.String()
+}
+
+func truncateHttpBody(body []byte) string {
+	if len(body) <= httpMaxOutputBytes {
+		return string(body)
+	}
+	return string(body[:httpMaxOutputBytes]) + fmt.Sprintf("... (%d bytes)", len(body))
+}
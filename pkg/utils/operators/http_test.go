@@ -0,0 +1,229 @@
+package operators
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_matchHttpStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		status   int
+		want     bool
+		wantErr  bool
+	}{
+		{name: "Test_matchHttpStatus_1", expected: "200-299", status: 204, want: true},
+		{name: "Test_matchHttpStatus_2", expected: "200-299, 304", status: 304, want: true},
+		{name: "Test_matchHttpStatus_3", expected: "200", status: 201, want: false},
+		{name: "Test_matchHttpStatus_4", expected: "2xx", status: 200, wantErr: true},
+		{name: "Test_matchHttpStatus_5", expected: "299-200", status: 200, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchHttpStatus(tt.expected, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchHttpStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("matchHttpStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_http_Run(t *testing.T) {
+	var failures int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reload":
+			body, _ := ioutil.ReadAll(r.Body)
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token-1" ||
+				r.Header.Get("Content-Type") != "application/json" || string(body) != `{"version":"1.0.0","user":"a\"b"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"task":{"id":42,"name":"reload"},"servers":["s1","s2"]}}`))
+		case "/flaky":
+			if atomic.AddInt32(&failures, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tests := []struct {
+		name            string
+		envs            map[string]string
+		sharingData     map[string]string
+		wantSharingData map[string]string
+		wantAttempts    int
+		wantErr         bool
+	}{
+		{
+			name: "Test_http_Run_1",
+			envs: map[string]string{
+				types.PublisherHttpMethod:  "post",
+				types.PublisherHttpUrl:     server.URL + "/reload",
+				types.PublisherHttpHeaders: "Authorization: Bearer {{.Envs.TOKEN}}\n",
+				types.PublisherHttpBody:    `{"version":{{json .SharingData.PUBLISHER_VERSION}},"user":{{json .Envs.USER}}}`,
+				types.PublisherHttpExtract: "TASK_ID=data.task.id, SERVER=data.servers.1\nTASK=data.task",
+				"TOKEN":                    "token-1",
+				"USER":                     `a"b`,
+			},
+			sharingData: map[string]string{types.PublisherVersion: "1.0.0"},
+			wantSharingData: map[string]string{
+				types.PublisherVersion:    "1.0.0",
+				types.PublisherHttpStatus: "200",
+				"TASK_ID":                 "42",
+				"SERVER":                  "s2",
+				"TASK":                    `{"id":42,"name":"reload"}`,
+			},
+			wantAttempts: 1,
+		},
+		{
+			name: "Test_http_Run_2",
+			envs: map[string]string{
+				types.PublisherHttpUrl:               server.URL + "/flaky",
+				types.PublisherHttpRetries:           "3",
+				types.PublisherHttpRetryIntervalInMs: "1",
+			},
+			wantSharingData: map[string]string{types.PublisherHttpStatus: "202"},
+			wantAttempts:    3,
+		},
+		{
+			name: "Test_http_Run_3",
+			envs: map[string]string{
+				types.PublisherHttpUrl:     server.URL + "/missing",
+				types.PublisherHttpRetries: "3",
+			},
+			wantSharingData: map[string]string{types.PublisherHttpStatus: "404"},
+			wantAttempts:    1,
+			wantErr:         true,
+		},
+		{
+			name: "Test_http_Run_4",
+			envs: map[string]string{
+				types.PublisherHttpUrl:            server.URL + "/missing",
+				types.PublisherHttpExpectedStatus: "404",
+			},
+			wantSharingData: map[string]string{types.PublisherHttpStatus: "404"},
+			wantAttempts:    1,
+		},
+		{
+			name: "Test_http_Run_5",
+			envs: map[string]string{
+				types.PublisherHttpUrl:            server.URL + "/missing",
+				types.PublisherHttpExpectedStatus: "404",
+				types.PublisherHttpExtract:        "ID=id",
+			},
+			wantSharingData: map[string]string{types.PublisherHttpStatus: "404"},
+			wantAttempts:    1,
+			wantErr:         true,
+		},
+		{
+			name:            "Test_http_Run_6",
+			envs:            map[string]string{types.PublisherHttpUrl: "ftp://example.com"},
+			wantSharingData: map[string]string{},
+			wantErr:         true,
+		},
+		{
+			name: "Test_http_Run_7",
+			envs: map[string]string{
+				types.PublisherHttpMethod:            http.MethodPost,
+				types.PublisherHttpUrl:               server.URL + "/flaky",
+				types.PublisherHttpRetries:           "3",
+				types.PublisherHttpRetryIntervalInMs: "1",
+			},
+			wantSharingData: map[string]string{types.PublisherHttpStatus: "503"},
+			wantAttempts:    1,
+			wantErr:         true,
+		},
+		{
+			name: "Test_http_Run_8",
+			envs: map[string]string{
+				types.PublisherHttpMethod:             http.MethodPost,
+				types.PublisherHttpUrl:                server.URL + "/flaky",
+				types.PublisherHttpRetries:            "3",
+				types.PublisherHttpRetryIntervalInMs:  "1",
+				types.PublisherHttpRetryNonIdempotent: "true",
+			},
+			wantSharingData: map[string]string{types.PublisherHttpStatus: "202"},
+			wantAttempts:    3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&failures, 0)
+			h := NewHttp("Http", "", "").(*httpHook)
+			for k, v := range tt.envs {
+				h.step.Envs[k] = v
+			}
+			for k, v := range tt.sharingData {
+				h.step.SharingData[k] = v
+			}
+			output := make(chan string, 100)
+			h.Prepare()
+			_, err := h.Run(output)
+			close(output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(h.step.SharingData, tt.wantSharingData) {
+				t.Errorf("Run() SharingData = %v, want %v", h.step.SharingData, tt.wantSharingData)
+			}
+			attempts := 0
+			for v := range output {
+				if strings.HasPrefix(v, "http responded") {
+					attempts++
+				}
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("Run() attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr && h.step.Phase != types.StepFailed {
+				t.Errorf("Run() phase = %v, want %v", h.step.Phase, types.StepFailed)
+			}
+		})
+	}
+}
+
+func Test_http_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	h := NewHttp("Http", http.MethodGet, server.URL).(*httpHook)
+	output := make(chan string, 100)
+	done := make(chan error)
+	go func() {
+		_, err := h.Run(output)
+		done <- err
+	}()
+	<-output
+	h.Cancel()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "was cancelled") {
+			t.Errorf("Run() error = %v, want cancelled", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Run() was not cancelled")
+	}
+}
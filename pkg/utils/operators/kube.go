@@ -0,0 +1,545 @@
+package operators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+)
+
+// NewKube returns the operator which updates the image of the workload and waits for its rollout,
+// the name should be unique in the Runner
+func NewKube(name, namespace, kind, workload string) interfaces.StepOperator {
+	envs := make(map[string]string, 0)
+	envs[types.PublisherKubeNamespace] = namespace
+	envs[types.PublisherKubeKind] = kind
+	envs[types.PublisherKubeName] = workload
+	return &kube{
+		clients: newKubeClients,
+		step: &types.Step{
+			Id:             0,
+			Name:           name,
+			Phase:          types.StepPending,
+			Policy:         types.StepPolicyAuto,
+			Available:      types.StepAvailableEnable,
+			Envs:           envs,
+			Messages:       make([]string, 0),
+			Output:         make([]string, 0),
+			SharingData:    make(map[string]string, 0),
+			SharingSetting: false,
+		},
+	}
+}
+
+const (
+	KubeKindDeployment  = "Deployment"
+	KubeKindStatefulSet = "StatefulSet"
+
+	ErrKubeApiServerEmpty     = "error: neither the kube_api_server nor the kube_config was set, and the Runner was not in a cluster"
+	ErrKubeNameEmpty          = "error: neither the kube_name nor the kube_manifest was set"
+	ErrKubeImageEmpty         = "error: the kube_image was empty and there was no PUBLISHER_DOCKER_IMAGE in the SharingData"
+	ErrKubeKindUnsupported    = "error: unsupported kube kind:%s, it should be Deployment or StatefulSet"
+	ErrKubeContainerNotFound  = "error: the container:%s was not found in %s"
+	ErrKubeContainerAmbiguous = "error: the kube_container should be set, because %s had %d containers"
+	ErrKubeManifestInvalid    = "error: the kube manifest #%d was invalid: %v"
+	ErrKubeEnvInvalid         = "error: the %s:%s was invalid"
+	ErrKubeRolloutFailed      = "error: kube %s rollout failed: %s"
+	ErrKubeRolloutTimeout     = "error: kube %s rollout was not finished before %s: %s"
+	ErrKubeCancelled          = "error: kube %s rollout was cancelled"
+
+	kubeDefaultNamespace    = "default"
+	kubeDefaultTimeout      = 300
+	kubeDefaultPollInterval = 2000
+	kubeFieldManager        = "publisher"
+	kubeRequestTimeout      = time.Minute
+	// kubeMaxDiagnostics limits the pod statuses and the events which were reported after the rollout failed
+	kubeMaxDiagnostics = 20
+)
+
+// kubeClients returns the clientset and the dynamic client of the Envs
+type kubeClients func(envs map[string]string) (kubernetes.Interface, dynamic.Interface, error)
+
+// kube implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator and Canceler
+type kube struct {
+	step    *types.Step
+	clients kubeClients
+	client  kubernetes.Interface
+	dynamic dynamic.Interface
+	output  chan<- string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// kubeRef was the workload which would be waited for
+type kubeRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (r kubeRef) String() string {
+	return strings.ToLower(r.kind) + "/" + r.namespace + "/" + r.name
+}
+
+func (k *kube) Step() *types.Step {
+	return k.step
+}
+
+func (k *kube) Update(s *types.Step) {
+	k.step = s.DeepCopy()
+}
+
+func (k *kube) Prepare() {
+	k.step.Messages = make([]string, 0)
+	k.step.Remarks = make([]string, 0)
+}
+
+// Cancel stops waiting for the rollout, the workload would not be rolled back
+func (k *kube) Cancel() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.cancel != nil {
+		k.cancel()
+	}
+}
+
+func (k *kube) Run(output chan<- string) (res []string, err error) {
+	k.step.Phase = types.StepRunning
+	k.output = output
+	if k.step.SharingData == nil {
+		k.step.SharingData = make(map[string]string, 0)
+	}
+	delete(k.step.SharingData, types.PublisherKubeImageName)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	k.mu.Lock()
+	k.cancel = cancel
+	k.mu.Unlock()
+	defer func() {
+		k.mu.Lock()
+		k.cancel = nil
+		k.mu.Unlock()
+	}()
+	if err = k.run(ctx); err != nil {
+		klog.V(2).Info(err)
+		k.step.Phase = types.StepFailed
+		return res, err
+	}
+	k.step.Phase = types.StepSucceeded
+	return res, nil
+}
+
+func (k *kube) run(ctx context.Context) (err error) {
+	if k.client, k.dynamic, err = k.clients(k.step.Envs); err != nil {
+		return err
+	}
+	timeout, err := k.integer(types.PublisherKubeTimeout, kubeDefaultTimeout)
+	if err != nil {
+		return err
+	}
+	interval, err := k.integer(types.PublisherKubePollIntervalInMs, kubeDefaultPollInterval)
+	if err != nil {
+		return err
+	}
+	var refs []kubeRef
+	if k.step.Envs[types.PublisherKubeManifest] != "" {
+		refs, err = k.apply()
+	} else {
+		refs, err = k.setImage()
+	}
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for _, ref := range refs {
+		start := time.Now()
+		if err = k.wait(ctx, ref, deadline, time.Duration(interval)*time.Millisecond); err != nil {
+			return err
+		}
+		k.step.Remarks = append(k.step.Remarks, fmt.Sprintf("kube rolled out %s in %s", ref, time.Since(start).Round(time.Millisecond)))
+	}
+	return nil
+}
+
+func (k *kube) namespace() string {
+	if v := k.step.Envs[types.PublisherKubeNamespace]; v != "" {
+		return v
+	}
+	return kubeDefaultNamespace
+}
+
+// integer returns the non-negative integer of the key, or the default one if it was empty
+func (k *kube) integer(key string, def int) (int, error) {
+	v := strings.TrimSpace(k.step.Envs[key])
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf(ErrKubeEnvInvalid, key, v)
+	}
+	return n, nil
+}
+
+// setImage patches the image of the container in the workload
+func (k *kube) setImage() ([]kubeRef, error) {
+	name := k.step.Envs[types.PublisherKubeName]
+	if name == "" {
+		return nil, errors.New(ErrKubeNameEmpty)
+	}
+	kind := k.step.Envs[types.PublisherKubeKind]
+	if kind == "" {
+		kind = KubeKindDeployment
+	}
+	ref := kubeRef{kind: kind, namespace: k.namespace(), name: name}
+	text := k.step.Envs[types.PublisherKubeImage]
+	if text == "" {
+		text = "{{.SharingData." + types.PublisherDockerImageName + "}}"
+	}
+	image, err := renderStepTemplate(text, newStepTemplateData(k.step))
+	if err != nil {
+		return nil, err
+	}
+	if image = strings.TrimSpace(image); image == "" {
+		return nil, errors.New(ErrKubeImageEmpty)
+	}
+	w, err := k.getWorkload(ref)
+	if err != nil {
+		return nil, err
+	}
+	template, _ := kubeWorkloadSpec(w)
+	containers := template.Spec.Containers
+	container := k.step.Envs[types.PublisherKubeContainer]
+	if container == "" {
+		if len(containers) != 1 {
+			return nil, fmt.Errorf(ErrKubeContainerAmbiguous, ref, len(containers))
+		}
+		container = containers[0].Name
+	}
+	found := false
+	for _, v := range containers {
+		if v.Name == container {
+			found = true
+			if v.Image == image {
+				k.output <- fmt.Sprintf("kube %s container %s was already %s", ref, container, image)
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf(ErrKubeContainerNotFound, container, ref)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []map[string]string{{"name": container, "image": image}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	k.output <- fmt.Sprintf("kube set image %s %s=%s", ref, container, image)
+	switch ref.kind {
+	case KubeKindDeployment:
+		_, err = k.client.AppsV1().Deployments(ref.namespace).Patch(ref.name, ktypes.StrategicMergePatchType, patch)
+	case KubeKindStatefulSet:
+		_, err = k.client.AppsV1().StatefulSets(ref.namespace).Patch(ref.name, ktypes.StrategicMergePatchType, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	k.step.SharingData[types.PublisherKubeImageName] = image
+	return []kubeRef{ref}, nil
+}
+
+// apply applies the rendered manifests by the server-side apply, and returns the workloads which would be waited for.
+// The resources of the kinds were resolved by the discovery of the API server.
+func (k *kube) apply() ([]kubeRef, error) {
+	text, err := renderStepTemplate(k.step.Envs[types.PublisherKubeManifest], newStepTemplateData(k.step))
+	if err != nil {
+		return nil, err
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(k.client.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(text), 4096)
+	refs := make([]kubeRef, 0)
+	force := true
+	for i := 1; ; i++ {
+		obj := &unstructured.Unstructured{}
+		if err = decoder.Decode(&obj.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf(ErrKubeManifestInvalid, i, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		gvk := obj.GroupVersionKind()
+		if gvk.Version == "" || gvk.Kind == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf(ErrKubeManifestInvalid, i, "the apiVersion, the kind and the metadata.name were required")
+		}
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, fmt.Errorf(ErrKubeManifestInvalid, i, err)
+		}
+		ref := kubeRef{kind: gvk.Kind, name: obj.GetName()}
+		var resource dynamic.ResourceInterface = k.dynamic.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if ref.namespace = obj.GetNamespace(); ref.namespace == "" {
+				ref.namespace = k.namespace()
+				obj.SetNamespace(ref.namespace)
+			}
+			resource = k.dynamic.Resource(mapping.Resource).Namespace(ref.namespace)
+		}
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		k.output <- fmt.Sprintf("kube applying %s", ref)
+		if _, err = resource.Patch(ref.name, ktypes.ApplyPatchType, data,
+			metav1.PatchOptions{FieldManager: kubeFieldManager, Force: &force}); err != nil {
+			return nil, err
+		}
+		if gvk.Group == appsv1.GroupName && (gvk.Kind == KubeKindDeployment || gvk.Kind == KubeKindStatefulSet) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// getWorkload returns the Deployment or the StatefulSet of the ref
+func (k *kube) getWorkload(ref kubeRef) (interface{}, error) {
+	switch ref.kind {
+	case KubeKindDeployment:
+		return k.client.AppsV1().Deployments(ref.namespace).Get(ref.name, metav1.GetOptions{})
+	case KubeKindStatefulSet:
+		return k.client.AppsV1().StatefulSets(ref.namespace).Get(ref.name, metav1.GetOptions{})
+	}
+	return nil, fmt.Errorf(ErrKubeKindUnsupported, ref.kind)
+}
+
+// kubeWorkloadSpec returns the pod template and the selector of the Deployment or the StatefulSet
+func kubeWorkloadSpec(w interface{}) (*corev1.PodTemplateSpec, *metav1.LabelSelector) {
+	switch v := w.(type) {
+	case *appsv1.Deployment:
+		return &v.Spec.Template, v.Spec.Selector
+	case *appsv1.StatefulSet:
+		return &v.Spec.Template, v.Spec.Selector
+	}
+	return &corev1.PodTemplateSpec{}, nil
+}
+
+// wait polls the workload until it was rolled out, and the pods and their events would be reported if it failed
+func (k *kube) wait(ctx context.Context, ref kubeRef, deadline time.Time, interval time.Duration) error {
+	last := ""
+	for {
+		w, err := k.getWorkload(ref)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf(ErrKubeCancelled, ref)
+			}
+			return err
+		}
+		message, done, err := kubeRolloutStatus(ref, w)
+		if message != last {
+			k.output <- message
+			last = message
+		}
+		if err != nil {
+			k.diagnose(ref, w)
+			return fmt.Errorf(ErrKubeRolloutFailed, ref, err)
+		}
+		if done {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			k.diagnose(ref, w)
+			return fmt.Errorf(ErrKubeRolloutTimeout, ref, deadline.Format("15:04:05"), message)
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return fmt.Errorf(ErrKubeCancelled, ref)
+		}
+	}
+}
+
+// kubeRolloutStatus returns the progress of the rollout as the `kubectl rollout status` did
+func kubeRolloutStatus(ref kubeRef, w interface{}) (message string, done bool, err error) {
+	switch v := w.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if v.Spec.Replicas != nil {
+			replicas = *v.Spec.Replicas
+		}
+		if v.Status.ObservedGeneration == 0 || v.Generation > v.Status.ObservedGeneration {
+			return fmt.Sprintf("Waiting for %s spec update to be observed...", ref), false, nil
+		}
+		for _, c := range v.Status.Conditions {
+			if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+				return fmt.Sprintf("%s exceeded its progress deadline", ref), false, errors.New(c.Message)
+			}
+		}
+		switch {
+		case v.Status.UpdatedReplicas < replicas:
+			return fmt.Sprintf("Waiting for %s rollout to finish: %d out of %d new replicas have been updated...",
+				ref, v.Status.UpdatedReplicas, replicas), false, nil
+		case v.Status.Replicas > v.Status.UpdatedReplicas:
+			return fmt.Sprintf("Waiting for %s rollout to finish: %d old replicas are pending termination...",
+				ref, v.Status.Replicas-v.Status.UpdatedReplicas), false, nil
+		case v.Status.AvailableReplicas < v.Status.UpdatedReplicas:
+			return fmt.Sprintf("Waiting for %s rollout to finish: %d of %d updated replicas are available...",
+				ref, v.Status.AvailableReplicas, v.Status.UpdatedReplicas), false, nil
+		}
+		return fmt.Sprintf("%s successfully rolled out", ref), true, nil
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if v.Spec.Replicas != nil {
+			replicas = *v.Spec.Replicas
+		}
+		if v.Status.ObservedGeneration == 0 || v.Generation > v.Status.ObservedGeneration {
+			return fmt.Sprintf("Waiting for %s spec update to be observed...", ref), false, nil
+		}
+		if v.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+			return fmt.Sprintf("%s was updated by OnDelete, the rollout was not waited for", ref), true, nil
+		}
+		if v.Status.ReadyReplicas < replicas {
+			return fmt.Sprintf("Waiting for %s: %d of %d pods are ready...", ref, v.Status.ReadyReplicas, replicas), false, nil
+		}
+		if ru := v.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 {
+			if v.Status.UpdatedReplicas < replicas-*ru.Partition {
+				return fmt.Sprintf("Waiting for %s partitioned roll out to finish: %d out of %d new pods have been updated...",
+					ref, v.Status.UpdatedReplicas, replicas-*ru.Partition), false, nil
+			}
+			return fmt.Sprintf("%s partitioned roll out complete: %d new pods have been updated", ref, v.Status.UpdatedReplicas), true, nil
+		}
+		if v.Status.UpdateRevision != v.Status.CurrentRevision {
+			return fmt.Sprintf("Waiting for %s rolling update to complete %d pods at revision %s...",
+				ref, v.Status.UpdatedReplicas, v.Status.UpdateRevision), false, nil
+		}
+		return fmt.Sprintf("%s rolling update complete %d pods at revision %s", ref, v.Status.ReadyReplicas, v.Status.CurrentRevision), true, nil
+	}
+	return "", false, fmt.Errorf(ErrKubeKindUnsupported, ref.kind)
+}
+
+// diagnose appends the waiting or terminated containers of the workload's pods and their warning events to the Messages
+func (k *kube) diagnose(ref kubeRef, w interface{}) {
+	_, selector := kubeWorkloadSpec(w)
+	if selector == nil {
+		return
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil || s.Empty() {
+		klog.V(2).Info(err)
+		return
+	}
+	pods, err := k.client.CoreV1().Pods(ref.namespace).List(metav1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		klog.V(2).Info(err)
+		return
+	}
+	lines := make([]string, 0)
+	for _, pod := range pods.Items {
+		for _, c := range pod.Status.ContainerStatuses {
+			if v := c.State.Waiting; v != nil && v.Reason != "" && v.Reason != "ContainerCreating" {
+				lines = append(lines, fmt.Sprintf("kube pod/%s container %s: %s %s", pod.Name, c.Name, v.Reason, v.Message))
+			}
+			if v := c.State.Terminated; v != nil && v.ExitCode != 0 {
+				lines = append(lines, fmt.Sprintf("kube pod/%s container %s: %s exit code %d", pod.Name, c.Name, v.Reason, v.ExitCode))
+			}
+		}
+		events, err := k.client.CoreV1().Events(ref.namespace).List(metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.name", pod.Name).String(),
+		})
+		if err != nil {
+			klog.V(2).Info(err)
+			continue
+		}
+		for _, e := range events.Items {
+			if e.Type == corev1.EventTypeWarning && e.InvolvedObject.Name == pod.Name {
+				lines = append(lines, fmt.Sprintf("kube pod/%s event %s: %s", pod.Name, e.Reason, strings.TrimSpace(e.Message)))
+			}
+		}
+	}
+	if len(lines) > kubeMaxDiagnostics {
+		lines = append(lines[:kubeMaxDiagnostics], fmt.Sprintf("kube %d more lines were omitted", len(lines)-kubeMaxDiagnostics))
+	}
+	for _, v := range lines {
+		k.output <- v
+	}
+	k.step.Messages = append(k.step.Messages, lines...)
+}
+
+// newKubeClients returns the clients of the rest config of the Envs
+func newKubeClients(envs map[string]string) (kubernetes.Interface, dynamic.Interface, error) {
+	config, err := newKubeConfig(envs)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, d, nil
+}
+
+// newKubeConfig returns the rest config of the kube_api_server, the kube_config, or the in-cluster service account in order
+func newKubeConfig(envs map[string]string) (config *rest.Config, err error) {
+	switch {
+	case envs[types.PublisherKubeApiServer] != "":
+		config = &rest.Config{
+			Host:        envs[types.PublisherKubeApiServer],
+			BearerToken: envs[types.PublisherKubeToken],
+			TLSClientConfig: rest.TLSClientConfig{
+				CAFile:   envs[types.PublisherKubeCaFile],
+				Insecure: envEnabled(envs, types.PublisherKubeInsecure),
+			},
+		}
+	case envs[types.PublisherKubeConfig] != "":
+		if config, err = clientcmd.BuildConfigFromFlags("", envs[types.PublisherKubeConfig]); err != nil {
+			return nil, err
+		}
+	default:
+		if config, err = rest.InClusterConfig(); err != nil {
+			if err == rest.ErrNotInCluster {
+				return nil, errors.New(ErrKubeApiServerEmpty)
+			}
+			return nil, err
+		}
+	}
+	config.Timeout = kubeRequestTimeout
+	return config, nil
+}
@@ -0,0 +1,423 @@
+package operators
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+// fakeKube was a fake Kubernetes cluster, the rollout of the deployment progressed one replica every poll
+type fakeKube struct {
+	mu      sync.Mutex
+	client  *fake.Clientset
+	dynamic *dynamicfake.FakeDynamicClient
+	broken  bool
+	gen     int64
+	updated int32
+	patches []string
+	applied []string
+}
+
+func newFakeKube(t *testing.T) *fakeKube {
+	replicas := int32(3)
+	f := &fakeKube{gen: 1, updated: 3}
+	f.client = fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "server", Namespace: "game"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "server", Image: "registry.example.com/game/server:0.9.0"}}},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "server-5d-x1", Namespace: "game", Labels: map[string]string{"app": "server"}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "server",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+				}},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "server-5d-x1.1", Namespace: "game"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "server-5d-x1"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Pulling",
+			Message:        "Pulling image",
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "server-5d-x1.2", Namespace: "game"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "server-5d-x1"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Failed",
+			Message:        "Failed to pull image: not found",
+		},
+	)
+	f.client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"}},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true, Kind: "Deployment"},
+				{Name: "statefulsets", Namespaced: true, Kind: "StatefulSet"},
+			},
+		},
+	}
+	// the patch was applied by the tracker after the new generation was recorded
+	f.client.PrependReactor("patch", "deployments", func(action ktesting.Action) (bool, runtime.Object, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.patches = append(f.patches, string(action.(ktesting.PatchAction).GetPatch()))
+		f.gen++
+		f.updated = 0
+		return false, nil, nil
+	})
+	f.client.PrependReactor("get", "deployments", func(action ktesting.Action) (bool, runtime.Object, error) {
+		obj, err := f.client.Tracker().Get(action.GetResource(), action.GetNamespace(), action.(ktesting.GetAction).GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.broken && f.updated < 3 {
+			f.updated++
+		}
+		d := obj.(*appsv1.Deployment).DeepCopy()
+		d.Generation = f.gen
+		d.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: f.gen,
+			Replicas:           3,
+			UpdatedReplicas:    f.updated,
+			AvailableReplicas:  f.updated,
+		}
+		if f.broken {
+			d.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: "ReplicaSet \"server-5d\" has timed out progressing.",
+			}}
+		}
+		return true, d, nil
+	})
+	f.dynamic = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	f.dynamic.PrependReactor("patch", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		patch := action.(ktesting.PatchAction)
+		if patch.GetPatchType() != ktypes.ApplyPatchType {
+			t.Errorf("apply patch type = %v, want %v", patch.GetPatchType(), ktypes.ApplyPatchType)
+		}
+		f.applied = append(f.applied, patch.GetResource().Resource+"/"+patch.GetNamespace()+"/"+patch.GetName()+"\n"+string(patch.GetPatch()))
+		return true, &unstructured.Unstructured{}, nil
+	})
+	return f
+}
+
+// image returns the image of the server container in the tracked deployment
+func (f *fakeKube) image(t *testing.T) string {
+	d, err := f.client.AppsV1().Deployments("game").Get("server", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d.Spec.Template.Spec.Containers[0].Image
+}
+
+func (f *fakeKube) clients(envs map[string]string) (kubernetes.Interface, dynamic.Interface, error) {
+	return f.client, f.dynamic, nil
+}
+
+func Test_newKubeConfig(t *testing.T) {
+	dir := t.TempDir()
+	kubeConfig := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(kubeConfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: game
+  cluster:
+    server: https://kube.example.com:6443
+users:
+- name: publisher
+  user:
+    token: token
+contexts:
+- name: game
+  context:
+    cluster: game
+    user: publisher
+current-context: game
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// the Runner was not in a cluster
+	for _, key := range []string{"KUBERNETES_SERVICE_HOST", "KUBERNETES_SERVICE_PORT"} {
+		if v, ok := os.LookupEnv(key); ok {
+			_ = os.Unsetenv(key)
+			defer os.Setenv(key, v)
+		}
+	}
+	tests := []struct {
+		name      string
+		envs      map[string]string
+		wantHost  string
+		wantToken string
+		wantErr   bool
+	}{
+		{
+			name: "Test_newKubeConfig_1",
+			envs: map[string]string{
+				types.PublisherKubeApiServer: "https://10.0.0.1:6443",
+				types.PublisherKubeToken:     "secret",
+				types.PublisherKubeConfig:    kubeConfig,
+			},
+			wantHost:  "https://10.0.0.1:6443",
+			wantToken: "secret",
+		},
+		{
+			name:      "Test_newKubeConfig_2",
+			envs:      map[string]string{types.PublisherKubeConfig: kubeConfig},
+			wantHost:  "https://kube.example.com:6443",
+			wantToken: "token",
+		},
+		{
+			name:    "Test_newKubeConfig_3",
+			envs:    map[string]string{types.PublisherKubeConfig: filepath.Join(dir, "missing")},
+			wantErr: true,
+		},
+		{
+			name:    "Test_newKubeConfig_4",
+			envs:    map[string]string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newKubeConfig(tt.envs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newKubeConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Host != tt.wantHost || got.BearerToken != tt.wantToken {
+				t.Errorf("newKubeConfig() = %v %v, want %v %v", got.Host, got.BearerToken, tt.wantHost, tt.wantToken)
+			}
+			if got.Timeout != kubeRequestTimeout {
+				t.Errorf("newKubeConfig() Timeout = %v, want %v", got.Timeout, kubeRequestTimeout)
+			}
+		})
+	}
+	if _, err := newKubeConfig(map[string]string{}); err == nil || err.Error() != ErrKubeApiServerEmpty {
+		t.Errorf("newKubeConfig() error = %v, want %v", err, ErrKubeApiServerEmpty)
+	}
+}
+
+func Test_kube_Run(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: conf
+data:
+  version: "{{.Version}}"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: server
+  namespace: game
+`
+	tests := []struct {
+		name            string
+		workload        string
+		envs            map[string]string
+		broken          bool
+		wantImage       string
+		wantApplied     int
+		wantSharingData map[string]string
+		wantMessages    []string
+		wantErr         bool
+	}{
+		{
+			name:      "Test_kube_Run_1",
+			workload:  "server",
+			wantImage: "registry.example.com/game/server:1.0.0",
+			wantSharingData: map[string]string{
+				types.PublisherVersion:         "1.0.0",
+				types.PublisherDockerImageName: "registry.example.com/game/server:1.0.0",
+				types.PublisherKubeImageName:   "registry.example.com/game/server:1.0.0",
+			},
+			wantMessages: []string{},
+		},
+		{
+			name:      "Test_kube_Run_2",
+			workload:  "server",
+			envs:      map[string]string{types.PublisherKubeImage: "registry.example.com/game/server:{{.Version}}-hotfix", types.PublisherKubeContainer: "server"},
+			wantImage: "registry.example.com/game/server:1.0.0-hotfix",
+			wantSharingData: map[string]string{
+				types.PublisherVersion:         "1.0.0",
+				types.PublisherDockerImageName: "registry.example.com/game/server:1.0.0",
+				types.PublisherKubeImageName:   "registry.example.com/game/server:1.0.0-hotfix",
+			},
+			wantMessages: []string{},
+		},
+		{
+			name:      "Test_kube_Run_3",
+			workload:  "server",
+			broken:    true,
+			wantImage: "registry.example.com/game/server:1.0.0",
+			wantSharingData: map[string]string{
+				types.PublisherVersion:         "1.0.0",
+				types.PublisherDockerImageName: "registry.example.com/game/server:1.0.0",
+				types.PublisherKubeImageName:   "registry.example.com/game/server:1.0.0",
+			},
+			wantMessages: []string{
+				"kube pod/server-5d-x1 container server: ImagePullBackOff Back-off pulling image",
+				"kube pod/server-5d-x1 event Failed: Failed to pull image: not found",
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Test_kube_Run_4",
+			workload:  "server",
+			envs:      map[string]string{types.PublisherKubeContainer: "sidecar"},
+			wantImage: "registry.example.com/game/server:0.9.0",
+			wantSharingData: map[string]string{
+				types.PublisherVersion:         "1.0.0",
+				types.PublisherDockerImageName: "registry.example.com/game/server:1.0.0",
+			},
+			wantMessages: []string{},
+			wantErr:      true,
+		},
+		{
+			name:      "Test_kube_Run_5",
+			workload:  "client",
+			wantImage: "registry.example.com/game/server:0.9.0",
+			wantSharingData: map[string]string{
+				types.PublisherVersion:         "1.0.0",
+				types.PublisherDockerImageName: "registry.example.com/game/server:1.0.0",
+			},
+			wantMessages: []string{},
+			wantErr:      true,
+		},
+		{
+			name:        "Test_kube_Run_6",
+			envs:        map[string]string{types.PublisherKubeManifest: manifest},
+			wantImage:   "registry.example.com/game/server:0.9.0",
+			wantApplied: 2,
+			wantSharingData: map[string]string{
+				types.PublisherVersion:         "1.0.0",
+				types.PublisherDockerImageName: "registry.example.com/game/server:1.0.0",
+			},
+			wantMessages: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeKube(t)
+			server.broken = tt.broken
+			k := NewKube("Kube", "game", KubeKindDeployment, tt.workload).(*kube)
+			k.clients = server.clients
+			k.step.Envs[types.PublisherKubePollIntervalInMs] = "1"
+			k.step.Envs[types.PublisherKubeTimeout] = "5"
+			for key, v := range tt.envs {
+				k.step.Envs[key] = v
+			}
+			k.step.SharingData[types.PublisherVersion] = "1.0.0"
+			k.step.SharingData[types.PublisherDockerImageName] = "registry.example.com/game/server:1.0.0"
+			output := make(chan string, 100)
+			k.Prepare()
+			_, err := k.Run(output)
+			close(output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(k.step.SharingData, tt.wantSharingData) {
+				t.Errorf("Run() SharingData = %v, want %v", k.step.SharingData, tt.wantSharingData)
+			}
+			if !reflect.DeepEqual(k.step.Messages, tt.wantMessages) {
+				t.Errorf("Run() Messages = %v, want %v", k.step.Messages, tt.wantMessages)
+			}
+			if got := server.image(t); got != tt.wantImage {
+				t.Errorf("Run() image = %v, want %v", got, tt.wantImage)
+			}
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.applied) != tt.wantApplied {
+				t.Fatalf("Run() applied = %v, want %d", server.applied, tt.wantApplied)
+			}
+			if tt.wantApplied > 0 {
+				if want := "configmaps/game/conf\n"; !strings.HasPrefix(server.applied[0], want) {
+					t.Errorf("Run() applied = %v, want %v", server.applied[0], want)
+				}
+				if want := "deployments/game/server\n"; !strings.HasPrefix(server.applied[1], want) {
+					t.Errorf("Run() applied = %v, want %v", server.applied[1], want)
+				}
+				if !strings.Contains(server.applied[0], `"version":"1.0.0"`) {
+					t.Errorf("Run() applied = %v, want the rendered version", server.applied[0])
+				}
+			}
+			lines := make([]string, 0)
+			for v := range output {
+				lines = append(lines, v)
+			}
+			if !tt.wantErr && !strings.Contains(strings.Join(lines, "\n"), "deployment/game/server successfully rolled out") {
+				t.Errorf("Run() output = %v, want the rollout progress", lines)
+			}
+			if !tt.wantErr && len(k.step.Remarks) != 1 {
+				t.Errorf("Run() Remarks = %v", k.step.Remarks)
+			}
+		})
+	}
+}
+
+func Test_kube_Cancel(t *testing.T) {
+	server := newFakeKube(t)
+	server.updated = 0
+	k := NewKube("Kube", "game", KubeKindDeployment, "server").(*kube)
+	k.clients = server.clients
+	k.step.Envs[types.PublisherKubeImage] = "registry.example.com/game/server:1.0.0"
+	k.step.Envs[types.PublisherKubePollIntervalInMs] = "60000"
+	output := make(chan string, 100)
+	done := make(chan error)
+	go func() {
+		_, err := k.Run(output)
+		done <- err
+	}()
+	// the second progress line was sent after the first poll
+	for v := range output {
+		if strings.Contains(v, "new replicas have been updated") {
+			break
+		}
+	}
+	k.Cancel()
+	if err := <-done; err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Run() error = %v, want cancelled", err)
+	}
+	if k.step.Phase != types.StepFailed {
+		t.Errorf("Run() Phase = %v, want %v", k.step.Phase, types.StepFailed)
+	}
+}
@@ -0,0 +1,179 @@
+package operators
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"unicode"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	DefaultReleaseDirTemplate = "{{.Date}}_{{.Seq}}"
+
+	ErrReleaseDirEmpty     = "error: the release directory template:%s rendered an empty name"
+	ErrReleaseDirInvalid   = "error: the release directory:%s should not contain the path separator"
+	ErrReleaseDirExhausted = "error: failed to create the release directory after %d attempts, last err:%v"
+	ErrReleaseDirPattern   = "error: the release directory template:%s had neither a literal part nor the Date or the Seq, it would match all the directories"
+	releaseDirMaxAttempts  = 100
+)
+
+// releaseDirTemplate returns the text and the parsed release directory template of the Step,
+// the DefaultReleaseDirTemplate would be used if it was not set
+func releaseDirTemplate(step *types.Step) (string, *template.Template, error) {
+	text := step.Envs[types.PublisherReleaseDirTemplate]
+	if text == "" {
+		text = DefaultReleaseDirTemplate
+	}
+	tmpl, err := parseStepTemplate("release", text)
+	return text, tmpl, err
+}
+
+// releaseFieldPatterns were the sub patterns of the fields in the stepTemplateData which changed between the releases
+var releaseFieldPatterns = map[string]string{
+	"Date":        `\d{8}`,
+	"Time":        `\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`,
+	"Seq":         `\d+`,
+	"Version":     `.+?`,
+	"User":        `.+?`,
+	"GitSha":      `[0-9a-fA-F]+`,
+	"GitShortSha": `[0-9a-fA-F]+`,
+}
+
+// releaseDirPattern returns the regexp which matches the names rendered by the release directory template,
+// the `seq` sub match was the sequence if the template referenced it. The fields which changed between the releases
+// were replaced by their sub patterns, and the other actions were rendered with the current Envs and SharingData.
+// The template without a literal part, the Date or the Seq was refused, such as `{{.Version}}`, because its pattern
+// would match the directories which were not the releases.
+func releaseDirPattern(step *types.Step) (*regexp.Regexp, error) {
+	text, tmpl, err := releaseDirTemplate(step)
+	if err != nil {
+		return nil, err
+	}
+	data := stepTemplateData{Envs: step.Envs, SharingData: step.SharingData}
+	nodes := tmpl.Tree.Root.Nodes
+	var b strings.Builder
+	hasSeq := false
+	specific := false
+	for i, node := range nodes {
+		if n, ok := node.(*parse.TextNode); ok {
+			text := string(n.Text)
+			// the rendered name was trimmed
+			if i == 0 {
+				text = strings.TrimLeftFunc(text, unicode.IsSpace)
+			}
+			if i == len(nodes)-1 {
+				text = strings.TrimRightFunc(text, unicode.IsSpace)
+			}
+			b.WriteString(regexp.QuoteMeta(text))
+			specific = specific || text != ""
+			continue
+		}
+		field := releaseActionField(node)
+		if field == "Seq" && !hasSeq {
+			hasSeq = true
+			specific = true
+			b.WriteString(`(?P<seq>\d+)`)
+			continue
+		}
+		if p, ok := releaseFieldPatterns[field]; ok {
+			b.WriteString(p)
+			specific = specific || field == "Date" || field == "Seq"
+			continue
+		}
+		t, err := parseStepTemplate("node", node.String())
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err = t.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		b.WriteString(regexp.QuoteMeta(buf.String()))
+		specific = specific || buf.Len() != 0
+	}
+	if !specific {
+		return nil, fmt.Errorf(ErrReleaseDirPattern, text)
+	}
+	return regexp.Compile("^" + b.String() + "$")
+}
+
+// releaseActionField returns the name of the field if the node was a single field action such as `{{.Seq}}`
+func releaseActionField(node parse.Node) string {
+	n, ok := node.(*parse.ActionNode)
+	if !ok || len(n.Pipe.Decl) != 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+		return ""
+	}
+	f, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(f.Ident) != 1 {
+		return ""
+	}
+	return f.Ident[0]
+}
+
+// createReleaseDir renders the release directory template with the increasing sequence,
+// skips the names in the existing, and calls the mkdir until it succeeds. The mkdir should fail
+// when the directory had been existed, so that the concurrent publishers would never share one.
+func createReleaseDir(step *types.Step, existing []string, mkdir func(name string) error) (string, error) {
+	text, tmpl, err := releaseDirTemplate(step)
+	if err != nil {
+		return "", err
+	}
+	data := newStepTemplateData(step)
+	skip := make(map[string]struct{}, len(existing))
+	for _, v := range existing {
+		skip[v] = struct{}{}
+	}
+	// the template without the sequence would always render the same name
+	hasSeq := strings.Contains(text, ".Seq")
+	var lastErr error
+	attempts := 0
+	for data.Seq = 1; attempts < releaseDirMaxAttempts && data.Seq <= len(existing)+releaseDirMaxAttempts; data.Seq++ {
+		var buf bytes.Buffer
+		if err = tmpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		name := strings.TrimSpace(buf.String())
+		if name == "" {
+			return "", fmt.Errorf(ErrReleaseDirEmpty, text)
+		}
+		if strings.ContainsAny(name, `/\`) {
+			return "", fmt.Errorf(ErrReleaseDirInvalid, name)
+		}
+		if _, ok := skip[name]; ok {
+			if !hasSeq {
+				break
+			}
+			continue
+		}
+		attempts++
+		if lastErr = mkdir(name); lastErr == nil {
+			return name, nil
+		}
+		klog.V(2).Infof("create the release directory:%s err:%v", name, lastErr)
+		skip[name] = struct{}{}
+		if !hasSeq {
+			break
+		}
+	}
+	if lastErr == nil {
+		lastErr = errors.New("all the names were existed")
+	}
+	return "", fmt.Errorf(ErrReleaseDirExhausted, attempts, lastErr)
+}
+
+// publishReleaseDir records the release directory into the Envs and the SharingData,
+// the PUBLISHER_FTP_MKDIR was kept being replaced by the directory as before
+func publishReleaseDir(step *types.Step, dir string) {
+	step.Envs[types.PublisherFtpMkdir] = dir
+	if step.SharingData == nil {
+		step.SharingData = make(map[string]string, 0)
+	}
+	step.SharingData[types.PublisherReleaseDir] = dir
+}
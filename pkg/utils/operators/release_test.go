@@ -0,0 +1,266 @@
+package operators
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_createReleaseDir(t *testing.T) {
+	date := time.Now().Format(stepTemplateDateFormat)
+	type args struct {
+		envs        map[string]string
+		sharingData map[string]string
+		existing    []string
+		// failures were the names which would be failed by the mkdir, such as being created concurrently
+		failures []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test_createReleaseDir_1",
+			args:    args{envs: map[string]string{}},
+			want:    date + "_1",
+			wantErr: false,
+		},
+		{
+			name: "Test_createReleaseDir_2",
+			args: args{
+				envs:     map[string]string{},
+				existing: []string{date + "_2", date + "_notes.txt", "latest.txt"},
+			},
+			want:    date + "_1",
+			wantErr: false,
+		},
+		{
+			name: "Test_createReleaseDir_3",
+			args: args{
+				envs:     map[string]string{},
+				existing: []string{date + "_1"},
+				failures: []string{date + "_2", date + "_3"},
+			},
+			want:    date + "_4",
+			wantErr: false,
+		},
+		{
+			name: "Test_createReleaseDir_4",
+			args: args{
+				envs: map[string]string{
+					types.PublisherReleaseDirTemplate: "v{{.Version}}-{{.GitShortSha}}-{{.Seq}}-{{index .Envs \"channel\"}}",
+					types.PublisherVersion:            "1.2.0",
+					"channel":                         "cn",
+				},
+				sharingData: map[string]string{types.PublisherGitShortSha: "9e8e0b3"},
+			},
+			want:    "v1.2.0-9e8e0b3-1-cn",
+			wantErr: false,
+		},
+		{
+			name: "Test_createReleaseDir_5",
+			args: args{
+				envs:     map[string]string{types.PublisherReleaseDirTemplate: "{{.Date}}"},
+				existing: []string{date},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test_createReleaseDir_6",
+			args: args{
+				envs:     map[string]string{types.PublisherReleaseDirTemplate: "{{.Date}}"},
+				failures: []string{date},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Test_createReleaseDir_7",
+			args:    args{envs: map[string]string{types.PublisherReleaseDirTemplate: "{{.Date}}/{{.Seq}}"}},
+			wantErr: true,
+		},
+		{
+			name:    "Test_createReleaseDir_8",
+			args:    args{envs: map[string]string{types.PublisherReleaseDirTemplate: "{{.Version}}"}},
+			wantErr: true,
+		},
+		{
+			name:    "Test_createReleaseDir_9",
+			args:    args{envs: map[string]string{types.PublisherReleaseDirTemplate: "{{.Date"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &types.Step{Envs: tt.args.envs, SharingData: tt.args.sharingData}
+			failures := make(map[string]bool, 0)
+			for _, v := range tt.args.failures {
+				failures[v] = true
+			}
+			got, err := createReleaseDir(step, tt.args.existing, func(name string) error {
+				if failures[name] {
+					return errors.New(fmt.Sprintf("550 %s: File exists", name))
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createReleaseDir() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("createReleaseDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_releaseDirPattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		envs      map[string]string
+		matched   []string
+		unmatched []string
+		wantErr   bool
+	}{
+		{
+			name:      "Test_releaseDirPattern_1",
+			envs:      map[string]string{},
+			matched:   []string{"20201030_1", "20201030_12"},
+			unmatched: []string{"20201030_notes.txt", "latest.txt", "2020103_1", "x20201030_1"},
+		},
+		{
+			name:      "Test_releaseDirPattern_2",
+			envs:      map[string]string{types.PublisherReleaseDirTemplate: "release-{{.Version}}.{{.GitSha}}-{{.Seq}}"},
+			matched:   []string{"release-1.2.0.9e8e0b3-1", "release-2.0.0-rc.1.abc123-7"},
+			unmatched: []string{"release-1.2.0.9e8e0b3-latest", "release-1.2.0.9e8e0b3-1.bak"},
+		},
+		{
+			name:      "Test_releaseDirPattern_3",
+			envs:      map[string]string{types.PublisherReleaseDirTemplate: "{{index .Envs \"channel\"}}_{{.Date}}", "channel": "cn"},
+			matched:   []string{"cn_20201030"},
+			unmatched: []string{"tw_20201030", "cn_20201030_1"},
+		},
+		{
+			name:      "Test_releaseDirPattern_4",
+			envs:      map[string]string{types.PublisherReleaseDirTemplate: " v{{.Seq}}-{{.Seq}} "},
+			matched:   []string{"v3-3", "v918273645-1"},
+			unmatched: []string{" v3-3", "v3-", "918273645"},
+		},
+		{
+			name:    "Test_releaseDirPattern_5",
+			envs:    map[string]string{types.PublisherReleaseDirTemplate: "{{.Version}}"},
+			wantErr: true,
+		},
+		{
+			name:    "Test_releaseDirPattern_6",
+			envs:    map[string]string{types.PublisherReleaseDirTemplate: " {{.Version}}{{.GitSha}}{{index .Envs \"missing\"}} "},
+			wantErr: true,
+		},
+		{
+			name:      "Test_releaseDirPattern_7",
+			envs:      map[string]string{types.PublisherReleaseDirTemplate: "{{.Version}}{{index .Envs \"channel\"}}", "channel": "cn"},
+			matched:   []string{"1.2.0cn"},
+			unmatched: []string{"1.2.0", "assets"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := releaseDirPattern(&types.Step{Envs: tt.envs})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("releaseDirPattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, v := range tt.matched {
+				if !got.MatchString(v) {
+					t.Errorf("releaseDirPattern() = %v, want matched %v", got, v)
+				}
+			}
+			for _, v := range tt.unmatched {
+				if got.MatchString(v) {
+					t.Errorf("releaseDirPattern() = %v, want unmatched %v", got, v)
+				}
+			}
+		})
+	}
+}
+
+func Test_releaseRetention_expired(t *testing.T) {
+	now := time.Now()
+	releases := func() []release {
+		return []release{
+			{name: "20201001_1", seq: 1, time: now.Add(-time.Hour * 24 * 30)},
+			{name: "20201020_1", seq: 1, time: now.Add(-time.Hour * 24 * 10)},
+			{name: "20201028_1", seq: 1, time: now.Add(-time.Hour * 24 * 2)},
+			{name: "20201030_2", seq: 2, time: now},
+			{name: "20201030_1", seq: 1, time: now},
+		}
+	}
+	names := func(res []release) []string {
+		s := make([]string, 0, len(res))
+		for _, v := range res {
+			s = append(s, v.name)
+		}
+		return s
+	}
+	tests := []struct {
+		name      string
+		envs      map[string]string
+		protected map[string]bool
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "Test_releaseRetention_expired_1",
+			envs: map[string]string{types.PublisherReleaseKeep: "2"},
+			want: []string{"20201028_1", "20201020_1", "20201001_1"},
+		},
+		{
+			name:      "Test_releaseRetention_expired_2",
+			envs:      map[string]string{types.PublisherReleaseKeep: "2"},
+			protected: map[string]bool{"20201001_1": true},
+			want:      []string{"20201028_1", "20201020_1"},
+		},
+		{
+			name: "Test_releaseRetention_expired_3",
+			envs: map[string]string{types.PublisherReleaseKeepDays: "7"},
+			want: []string{"20201020_1", "20201001_1"},
+		},
+		{
+			name: "Test_releaseRetention_expired_4",
+			envs: map[string]string{types.PublisherReleaseKeep: "1", types.PublisherReleaseKeepDays: "15"},
+			want: []string{"20201001_1"},
+		},
+		{
+			name: "Test_releaseRetention_expired_5",
+			envs: map[string]string{types.PublisherReleaseKeep: "0"},
+			want: []string{"20201030_2", "20201030_1", "20201028_1", "20201020_1", "20201001_1"},
+		},
+		{
+			name:    "Test_releaseRetention_expired_6",
+			envs:    map[string]string{types.PublisherReleaseKeep: "-1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newReleaseRetention(tt.envs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newReleaseRetention() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := names(r.expired(releases(), tt.protected, now)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("releaseRetention.expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
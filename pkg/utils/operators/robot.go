@@ -2,7 +2,14 @@ package operators
 
 import (
 	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
 )
 
 func NewRobot(p types.StepPolicy, durationInMs int64) *robot {
@@ -20,13 +27,27 @@ func NewRobot(p types.StepPolicy, durationInMs int64) *robot {
 			SharingData:    make(map[string]string, 0),
 			SharingSetting: false,
 		},
+		random: rand.New(rand.NewSource(time.Now().UnixNano())).Float64,
 	}
 }
 
-// robot implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator
+const (
+	ErrRobotEnvInvalid   = "error: the %s:%s was invalid"
+	ErrRobotFailed       = "error: the robot failed as the %s"
+	ErrRobotCancelled    = "error: the robot was cancelled after %s"
+	robotDefaultInterval = time.Second
+)
+
+// robot implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator and Canceler.
+// It was a test double which simulates the work for rehearsing the pipelines.
 type robot struct {
 	step        *types.Step
 	prepareFunc func()
+	// random returns the number in [0.0,1.0) for the failure probability
+	random func() float64
+
+	mu     sync.Mutex
+	cancel chan struct{}
 }
 
 func (r *robot) Step() *types.Step {
@@ -42,9 +63,118 @@ func (r *robot) SettingPrepareFunc(fc func()) {
 }
 
 func (r *robot) Prepare() {
-	r.prepareFunc()
+	r.step.Remarks = make([]string, 0)
+	if r.prepareFunc != nil {
+		r.prepareFunc()
+	}
+}
+
+// Cancel stops the running Step, it does nothing if the robot was not running
+func (r *robot) Cancel() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		close(r.cancel)
+		r.cancel = nil
+	}
 }
 
+// Run sleeps for the Robot_Duration_In_MS while emitting the output lines periodically,
+// and then it fails if the Robot_Fail was true or by the Robot_Failure_Probability
 func (r *robot) Run(output chan<- string) (res []string, err error) {
+	r.step.Phase = types.StepRunning
+	duration, err := r.duration(types.RobotDurationInMs, 0)
+	if err != nil {
+		klog.V(2).Info(err)
+		r.step.Phase = types.StepFailed
+		return res, err
+	}
+	interval, err := r.duration(types.RobotIntervalInMs, robotDefaultInterval)
+	if err != nil {
+		klog.V(2).Info(err)
+		r.step.Phase = types.StepFailed
+		return res, err
+	}
+	if interval <= 0 {
+		interval = robotDefaultInterval
+	}
+	fail, probability, err := r.failure()
+	if err != nil {
+		klog.V(2).Info(err)
+		r.step.Phase = types.StepFailed
+		return res, err
+	}
+	cancel := make(chan struct{})
+	r.mu.Lock()
+	r.cancel = cancel
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		if r.cancel == cancel {
+			r.cancel = nil
+		}
+		r.mu.Unlock()
+	}()
+
+	output <- fmt.Sprintf("robot started, it would run for %s", duration)
+	start := time.Now()
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+run:
+	for {
+		select {
+		case <-timer.C:
+			break run
+		case <-ticker.C:
+			output <- fmt.Sprintf("robot was running, %s elapsed", time.Since(start).Truncate(time.Millisecond))
+		case <-cancel:
+			err = fmt.Errorf(ErrRobotCancelled, time.Since(start).Truncate(time.Millisecond))
+			klog.V(2).Info(err)
+			r.step.Phase = types.StepFailed
+			return res, err
+		}
+	}
+	switch {
+	case fail:
+		err = fmt.Errorf(ErrRobotFailed, types.RobotFail)
+	case probability > 0 && r.random() < probability:
+		err = fmt.Errorf(ErrRobotFailed, types.RobotFailureProbability)
+	}
+	if err != nil {
+		klog.V(2).Info(err)
+		r.step.Phase = types.StepFailed
+		return res, err
+	}
+	r.step.Remarks = append(r.step.Remarks, fmt.Sprintf("robot ran for %s", duration))
+	r.step.Phase = types.StepSucceeded
 	return res, nil
 }
+
+// duration returns the milliseconds of the key as the duration, or the default one if it was empty
+func (r *robot) duration(key string, def time.Duration) (time.Duration, error) {
+	v := strings.TrimSpace(r.step.Envs[key])
+	if v == "" {
+		return def, nil
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf(ErrRobotEnvInvalid, key, v)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func (r *robot) failure() (fail bool, probability float64, err error) {
+	if v := strings.TrimSpace(r.step.Envs[types.RobotFail]); v != "" {
+		if fail, err = strconv.ParseBool(v); err != nil {
+			return false, 0, fmt.Errorf(ErrRobotEnvInvalid, types.RobotFail, v)
+		}
+	}
+	if v := strings.TrimSpace(r.step.Envs[types.RobotFailureProbability]); v != "" {
+		if probability, err = strconv.ParseFloat(v, 64); err != nil || probability < 0 || probability > 1 {
+			return false, 0, fmt.Errorf(ErrRobotEnvInvalid, types.RobotFailureProbability, v)
+		}
+	}
+	return fail, probability, nil
+}
@@ -0,0 +1,117 @@
+package operators
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_robot_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		envs        map[string]string
+		random      float64
+		wantTicks   bool
+		wantRemarks []string
+		wantErr     string
+	}{
+		{
+			name:        "Test_robot_Run_1",
+			envs:        map[string]string{types.RobotDurationInMs: "60", types.RobotIntervalInMs: "10"},
+			wantTicks:   true,
+			wantRemarks: []string{"robot ran for 60ms"},
+		},
+		{
+			name:    "Test_robot_Run_2",
+			envs:    map[string]string{types.RobotDurationInMs: "10", types.RobotFail: "true"},
+			wantErr: "error: the robot failed as the Robot_Fail",
+		},
+		{
+			name:    "Test_robot_Run_3",
+			envs:    map[string]string{types.RobotDurationInMs: "10", types.RobotFailureProbability: "0.5"},
+			random:  0.2,
+			wantErr: "error: the robot failed as the Robot_Failure_Probability",
+		},
+		{
+			name:        "Test_robot_Run_4",
+			envs:        map[string]string{types.RobotDurationInMs: "10", types.RobotFailureProbability: "0.5"},
+			random:      0.8,
+			wantRemarks: []string{"robot ran for 10ms"},
+		},
+		{
+			name:    "Test_robot_Run_5",
+			envs:    map[string]string{types.RobotDurationInMs: "ten"},
+			wantErr: "error: the Robot_Duration_In_MS:ten was invalid",
+		},
+		{
+			name:    "Test_robot_Run_6",
+			envs:    map[string]string{types.RobotDurationInMs: "10", types.RobotFailureProbability: "2"},
+			wantErr: "error: the Robot_Failure_Probability:2 was invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRobot(types.StepPolicyAuto, 0)
+			r.step.Envs = tt.envs
+			random := tt.random
+			r.random = func() float64 { return random }
+			output := make(chan string, 100)
+			r.Prepare()
+			_, err := r.Run(output)
+			close(output)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if r.step.Phase != types.StepFailed {
+					t.Errorf("Run() phase = %v, want %v", r.step.Phase, types.StepFailed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !reflect.DeepEqual(r.step.Remarks, tt.wantRemarks) {
+				t.Errorf("Run() Remarks = %v, want %v", r.step.Remarks, tt.wantRemarks)
+			}
+			ticks := 0
+			for v := range output {
+				if strings.HasPrefix(v, "robot was running") {
+					ticks++
+				}
+			}
+			if tt.wantTicks && ticks == 0 {
+				t.Errorf("Run() emitted no running lines")
+			}
+		})
+	}
+}
+
+func Test_robot_Cancel(t *testing.T) {
+	r := NewRobot(types.StepPolicyAuto, 10000)
+	output := make(chan string, 100)
+	done := make(chan error)
+	go func() {
+		_, err := r.Run(output)
+		done <- err
+	}()
+	<-output
+	start := time.Now()
+	r.Cancel()
+	select {
+	case err := <-done:
+		if err == nil || !strings.HasPrefix(err.Error(), "error: the robot was cancelled") {
+			t.Errorf("Run() error = %v, want cancelled", err)
+		}
+		if r.step.Phase != types.StepFailed {
+			t.Errorf("Run() phase = %v, want %v", r.step.Phase, types.StepFailed)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Run() was not cancelled after %s", time.Since(start))
+	}
+	// cancelling the idle robot does nothing
+	r.Cancel()
+}
@@ -0,0 +1,195 @@
+package operators
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// NewScript returns the operator which runs the script body in the work dir, the name should be unique in the Runner
+func NewScript(name, body, workDir string) interfaces.StepOperator {
+	envs := make(map[string]string, 0)
+	envs[types.PublisherScriptBody] = body
+	envs[types.PublisherScriptWorkDir] = workDir
+	return &script{
+		step: &types.Step{
+			Id:             0,
+			Name:           name,
+			Phase:          types.StepPending,
+			Policy:         types.StepPolicyAuto,
+			Available:      types.StepAvailableEnable,
+			Envs:           envs,
+			Messages:       make([]string, 0),
+			Output:         make([]string, 0),
+			SharingData:    make(map[string]string, 0),
+			SharingSetting: false,
+		},
+	}
+}
+
+const (
+	// ScriptSetSharing was the prefix of the stdout line which sets the SharingData, such as `::set-sharing key=value`
+	ScriptSetSharing = "::set-sharing "
+	// ScriptAddRemark was the prefix of the stdout line which appends the Remarks, such as `::add-remark text`
+	ScriptAddRemark = "::add-remark "
+
+	ErrScriptEmpty      = "error: neither the script_body nor the script_path was set"
+	ErrScriptSetSharing = "error: the script line:%q should be `::set-sharing key=value`"
+	scriptDefaultShell  = "sh"
+	scriptAction        = "script"
+	scriptStderrTail    = 20
+)
+
+// script implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator
+type script struct {
+	step *types.Step
+}
+
+func (s *script) Step() *types.Step {
+	return s.step
+}
+
+func (s *script) Update(step *types.Step) {
+	s.step = step.DeepCopy()
+}
+
+func (s *script) Prepare() {
+	s.step.Messages = make([]string, 0)
+	s.step.Remarks = make([]string, 0)
+}
+
+func (s *script) Run(output chan<- string) (res []string, err error) {
+	s.step.Phase = types.StepRunning
+	if s.step.SharingData == nil {
+		s.step.SharingData = make(map[string]string, 0)
+	}
+	cmd, cleanup, err := s.command()
+	if err != nil {
+		klog.V(2).Info(err)
+		s.step.Phase = types.StepFailed
+		return res, err
+	}
+	defer cleanup()
+	s.step.Messages = append(s.step.Messages, types.StepMessage(s.step.Name, scriptAction))
+	var lineErr error
+	err = execCmdWithStreams(cmd, scriptStderrTail, func(line StreamLine) {
+		if !line.Stderr {
+			handled, err := s.handleLine(line.Text)
+			if err != nil && lineErr == nil {
+				lineErr = err
+			}
+			if handled {
+				return
+			}
+		}
+		if line.Stderr {
+			output <- StderrPrefix + line.Text
+			return
+		}
+		output <- line.Text
+	})
+	if err == nil {
+		err = lineErr
+	}
+	if err != nil {
+		klog.V(2).Info(err)
+		s.step.Phase = types.StepFailed
+		return res, err
+	}
+	s.step.Phase = types.StepSucceeded
+	return res, nil
+}
+
+// handleLine handles the commands in the stdout of the script, and it returns whether the line was a command
+func (s *script) handleLine(text string) (bool, error) {
+	switch {
+	case strings.HasPrefix(text, ScriptSetSharing):
+		kv := strings.SplitN(strings.TrimPrefix(text, ScriptSetSharing), "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return true, fmt.Errorf(ErrScriptSetSharing, text)
+		}
+		s.step.SharingData[key] = kv[1]
+		return true, nil
+	case strings.HasPrefix(text, ScriptAddRemark):
+		s.step.Remarks = append(s.step.Remarks, strings.TrimPrefix(text, ScriptAddRemark))
+		return true, nil
+	}
+	return false, nil
+}
+
+// command returns the command of the script and the func which removes the temporary script file.
+// The SharingData and the Envs in the script_envs would be exported as the environment variables, and the Envs take
+// precedence. The other Envs were never exported, because they may contain the passwords of the other operators.
+func (s *script) command() (cmd *exec.Cmd, cleanup func(), err error) {
+	cleanup = func() {}
+	shell := s.step.Envs[types.PublisherScriptShell]
+	if body := s.step.Envs[types.PublisherScriptBody]; body != "" {
+		f, err := ioutil.TempFile("", "publisher-script-*")
+		if err != nil {
+			return nil, cleanup, err
+		}
+		cleanup = func() {
+			if err := os.Remove(f.Name()); err != nil {
+				klog.V(2).Info(err)
+			}
+		}
+		_, err = f.WriteString(body)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return nil, func() {}, err
+		}
+		if shell == "" {
+			shell = scriptDefaultShell
+		}
+		cmd = exec.Command(shell, f.Name())
+	} else if path := s.step.Envs[types.PublisherScriptPath]; path != "" {
+		if shell == "" {
+			cmd = exec.Command(path)
+		} else {
+			cmd = exec.Command(shell, path)
+		}
+	} else {
+		return nil, cleanup, errors.New(ErrScriptEmpty)
+	}
+	cmd.Dir = s.step.Envs[types.PublisherScriptWorkDir]
+	cmd.Env = append(os.Environ(), scriptEnviron(s.step.SharingData)...)
+	cmd.Env = append(cmd.Env, scriptEnviron(scriptExportedEnvs(s.step.Envs))...)
+	return cmd, cleanup, nil
+}
+
+// scriptExportedEnvs returns the Envs whose keys were listed in the comma separated script_envs
+func scriptExportedEnvs(envs map[string]string) map[string]string {
+	res := make(map[string]string, 0)
+	for _, k := range strings.Split(envs[types.PublisherScriptEnvs], ",") {
+		k = strings.TrimSpace(k)
+		if v, ok := envs[k]; ok {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// scriptEnviron returns the sorted `key=value` pairs, the keys which could not be the environment variables were skipped
+func scriptEnviron(m map[string]string) []string {
+	res := make([]string, 0, len(m))
+	for k, v := range m {
+		if k == "" || strings.ContainsAny(k, "=\x00") || strings.Contains(v, "\x00") {
+			continue
+		}
+		res = append(res, k+"="+v)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,132 @@
+package operators
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_script_Run(t *testing.T) {
+	workDir := t.TempDir()
+	path := filepath.Join(workDir, "build.sh")
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\necho \"built $PUBLISHER_VERSION\"\necho \"::set-sharing PUBLISHER_BUILD=$(pwd)\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name            string
+		envs            map[string]string
+		sharingData     map[string]string
+		wantOutput      []string
+		wantSharingData map[string]string
+		wantRemarks     []string
+		wantMessage     string
+		wantErr         bool
+	}{
+		{
+			name: "Test_script_Run_1",
+			envs: map[string]string{
+				types.PublisherScriptBody: "echo \"version:$PUBLISHER_VERSION sha:$PUBLISHER_GIT_SHORT_SHA\"\n" +
+					"echo warning >&2\n" +
+					"echo '::set-sharing PUBLISHER_ARTIFACT=a b=c'\n" +
+					"echo '::add-remark built the artifact'\n",
+				types.PublisherVersion:    "1.0.0",
+				types.PublisherScriptEnvs: types.PublisherVersion,
+			},
+			sharingData:     map[string]string{types.PublisherGitShortSha: "9e8e0b3", types.PublisherVersion: "0.9.0"},
+			wantOutput:      []string{"version:1.0.0 sha:9e8e0b3", StderrPrefix + "warning"},
+			wantSharingData: map[string]string{types.PublisherGitShortSha: "9e8e0b3", types.PublisherVersion: "0.9.0", "PUBLISHER_ARTIFACT": "a b=c"},
+			wantRemarks:     []string{"built the artifact"},
+			wantMessage:     "Message: [script] is starting",
+		},
+		{
+			name: "Test_script_Run_2",
+			envs: map[string]string{
+				types.PublisherScriptBody: "echo started\necho 'fatal: not found' >&2\nexit 3\n",
+			},
+			wantOutput:  []string{"started", StderrPrefix + "fatal: not found"},
+			wantMessage: "Message: [script] is starting",
+			wantErr:     true,
+		},
+		{
+			name: "Test_script_Run_3",
+			envs: map[string]string{
+				types.PublisherScriptBody: "",
+				types.PublisherScriptPath: path,
+				types.PublisherVersion:    "2.0.0",
+				types.PublisherScriptEnvs: "PUBLISHER_GIT_SHA, " + types.PublisherVersion,
+			},
+			wantOutput:      []string{"built 2.0.0"},
+			wantSharingData: map[string]string{"PUBLISHER_BUILD": workDir},
+			wantMessage:     "Message: [script] is starting",
+		},
+		{
+			name: "Test_script_Run_4",
+			envs: map[string]string{
+				types.PublisherScriptBody: "echo '::set-sharing invalid'\n",
+			},
+			wantMessage: "Message: [script] is starting",
+			wantErr:     true,
+		},
+		{
+			name:    "Test_script_Run_5",
+			envs:    map[string]string{types.PublisherScriptBody: ""},
+			wantErr: true,
+		},
+		{
+			name: "Test_script_Run_6",
+			envs: map[string]string{
+				types.PublisherScriptBody:  "echo \"password:$ftp_password version:$PUBLISHER_VERSION\"\n",
+				types.PublisherFtpPassword: "secret",
+				types.PublisherVersion:     "1.0.0",
+			},
+			wantOutput:  []string{"password: version:"},
+			wantMessage: "Message: [script] is starting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScript("Build", "", workDir).(*script)
+			for k, v := range tt.envs {
+				s.step.Envs[k] = v
+			}
+			for k, v := range tt.sharingData {
+				s.step.SharingData[k] = v
+			}
+			s.Prepare()
+			output := make(chan string, 4096)
+			_, err := s.Run(output)
+			close(output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("script.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := make([]string, 0)
+			for v := range output {
+				got = append(got, v)
+			}
+			// the stdout and the stderr were read concurrently, so the order between them was not guaranteed
+			sort.Strings(got)
+			sort.Strings(tt.wantOutput)
+			if tt.wantOutput != nil && !reflect.DeepEqual(got, tt.wantOutput) {
+				t.Errorf("script.Run() output = %v, want %v", got, tt.wantOutput)
+			}
+			if tt.wantSharingData != nil && !reflect.DeepEqual(s.step.SharingData, tt.wantSharingData) {
+				t.Errorf("script.Run() SharingData = %v, want %v", s.step.SharingData, tt.wantSharingData)
+			}
+			if tt.wantRemarks != nil && !reflect.DeepEqual(s.step.Remarks, tt.wantRemarks) {
+				t.Errorf("script.Run() Remarks = %v, want %v", s.step.Remarks, tt.wantRemarks)
+			}
+			if tt.wantMessage != "" && (len(s.step.Messages) == 0 || !strings.Contains(s.step.Messages[len(s.step.Messages)-1], tt.wantMessage)) {
+				t.Errorf("script.Run() Messages = %v, want %s", s.step.Messages, tt.wantMessage)
+			}
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("the script file was removed: %v", err)
+			}
+		})
+	}
+}
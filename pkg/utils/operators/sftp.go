@@ -0,0 +1,276 @@
+package operators
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/klog/v2"
+)
+
+func NewSftp(host string, port int, username, password, privateKey, hostKey, workDir string, timeout int) *sftpOperator {
+	envs := make(map[string]string, 0)
+	envs[types.PublisherSftpHost] = host
+	envs[types.PublisherSftpPort] = fmt.Sprintf("%d", port)
+	envs[types.PublisherSftpUsername] = username
+	envs[types.PublisherSftpPassword] = password
+	envs[types.PublisherSftpPrivateKey] = privateKey
+	envs[types.PublisherSftpHostKey] = hostKey
+	envs[types.PublisherSftpWorkDir] = workDir
+	envs[types.PublisherSftpTimeout] = fmt.Sprintf("%d", timeout)
+	return &sftpOperator{
+		step: &types.Step{
+			Id:             0,
+			Name:           "Sftp-Operator",
+			Phase:          types.StepPending,
+			Policy:         types.StepPolicyAuto,
+			Available:      types.StepAvailableEnable,
+			Envs:           envs,
+			Messages:       make([]string, 0),
+			Output:         make([]string, 0),
+			SharingData:    make(map[string]string, 0),
+			SharingSetting: false,
+		},
+	}
+}
+
+const (
+	// SftpHostKeyInsecure skips the host key verification explicitly, it should only be used for testing
+	SftpHostKeyInsecure = "insecure"
+
+	ErrSftpHostKeyRequired  = "error: the sftp host key was required, set %s to `%s` to skip the verification"
+	ErrSftpHostKeyMismatch  = "error: the sftp host key fingerprint:%s was not matched the pinned:%s"
+	ErrSftpAuthRequired     = "error: neither the sftp password nor the private key was set"
+	SftpFingerprintSHA256   = "SHA256:"
+	sftpDefaultTimeoutInSec = 30
+)
+
+// sftpOperator implements github.com/Shanghai-Lunara/publisher/pkg/interfaces.StepOperator
+type sftpOperator struct {
+	output  chan<- string
+	step    *types.Step
+	workDir string
+	client  *sftp.Client
+}
+
+func (s *sftpOperator) Step() *types.Step {
+	return s.step
+}
+
+func (s *sftpOperator) Update(step *types.Step) {
+	s.step = step.DeepCopy()
+}
+
+func (s *sftpOperator) Prepare() {
+	s.step.Messages = make([]string, 0)
+	s.step.Remarks = make([]string, 0)
+}
+
+func (s *sftpOperator) Run(output chan<- string) (res []string, err error) {
+	s.output = output
+	s.step.Phase = types.StepRunning
+	var conn *ssh.Client
+	if conn, err = s.dial(); err != nil {
+		klog.V(2).Info(err)
+		s.step.Phase = types.StepFailed
+		return res, err
+	}
+	defer conn.Close()
+	if s.client, err = sftp.NewClient(conn); err != nil {
+		klog.V(2).Info(err)
+		s.step.Phase = types.StepFailed
+		return res, err
+	}
+	defer s.client.Close()
+	s.workDir = s.step.Envs[types.PublisherSftpWorkDir]
+	prefix := ""
+	if mark, ok := s.step.Envs[types.PublisherFtpMkdir]; ok && mark == FtpMkdirMark {
+		if prefix, err = s.mkdirRelease(); err != nil {
+			klog.V(2).Info(err)
+			s.step.Phase = types.StepFailed
+			return res, err
+		}
+		publishReleaseDir(s.step, prefix)
+		s.output <- fmt.Sprintf("sftp created the release directory %s", prefix)
+	}
+	files, err := stepUploadFiles(s.step)
+	if err != nil {
+		klog.V(2).Info(err)
+		s.step.Phase = types.StepFailed
+		return res, err
+	}
+	tasks, err := expandUploadFiles(files)
+	if err != nil {
+		klog.V(2).Info(err)
+		s.step.Phase = types.StepFailed
+		return res, err
+	}
+	for _, v := range tasks {
+		if err = s.uploadFile(v.source, s.target(prefix, v.target)); err != nil {
+			klog.V(2).Info(err)
+			s.step.Phase = types.StepFailed
+			return res, err
+		}
+	}
+	for _, v := range s.step.WriteFiles {
+		if err = s.writeFile(bytes.NewReader(v.Content), s.target(prefix, v.TargetFile)); err != nil {
+			klog.V(2).Info(err)
+			s.step.Phase = types.StepFailed
+			return res, err
+		}
+	}
+	if prefix != "" {
+		if err = s.updateLatest(prefix); err != nil {
+			klog.V(2).Info(err)
+			s.step.Phase = types.StepFailed
+			return res, err
+		}
+	}
+	s.step.Phase = types.StepSucceeded
+	return res, nil
+}
+
+func (s *sftpOperator) dial() (*ssh.Client, error) {
+	timeout, err := strconv.Atoi(s.step.Envs[types.PublisherSftpTimeout])
+	if err != nil || timeout <= 0 {
+		timeout = sftpDefaultTimeoutInSec
+	}
+	auth := make([]ssh.AuthMethod, 0)
+	if keyFile := s.step.Envs[types.PublisherSftpPrivateKey]; keyFile != "" {
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if password := s.step.Envs[types.PublisherSftpPassword]; password != "" {
+		auth = append(auth, ssh.Password(password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New(ErrSftpAuthRequired)
+	}
+	hostKeyCallback, err := sftpHostKeyCallback(s.step.Envs[types.PublisherSftpHostKey])
+	if err != nil {
+		return nil, err
+	}
+	c := &ssh.ClientConfig{
+		User:            s.step.Envs[types.PublisherSftpUsername],
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         time.Second * time.Duration(timeout),
+	}
+	addr := net.JoinHostPort(s.step.Envs[types.PublisherSftpHost], s.step.Envs[types.PublisherSftpPort])
+	return ssh.Dial("tcp", addr, c)
+}
+
+// sftpHostKeyCallback pins the host key by an authorized_keys line or a `SHA256:` fingerprint
+func sftpHostKeyCallback(pinned string) (ssh.HostKeyCallback, error) {
+	pinned = strings.TrimSpace(pinned)
+	switch {
+	case pinned == "":
+		return nil, fmt.Errorf(ErrSftpHostKeyRequired, types.PublisherSftpHostKey, SftpHostKeyInsecure)
+	case pinned == SftpHostKeyInsecure:
+		klog.Warning("the sftp host key verification was skipped")
+		return ssh.InsecureIgnoreHostKey(), nil
+	case strings.HasPrefix(pinned, SftpFingerprintSHA256):
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if fp := ssh.FingerprintSHA256(key); fp != pinned {
+				return fmt.Errorf(ErrSftpHostKeyMismatch, fp, pinned)
+			}
+			return nil
+		}, nil
+	default:
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinned))
+		if err != nil {
+			return nil, err
+		}
+		return ssh.FixedHostKey(key), nil
+	}
+}
+
+func (s *sftpOperator) target(prefix, file string) string {
+	if prefix != "" {
+		return path.Join(s.workDir, prefix, file)
+	}
+	return path.Join(s.workDir, file)
+}
+
+// mkdirRelease creates the release directory in the work dir, which was the same as the ftp operator
+func (s *sftpOperator) mkdirRelease() (dir string, err error) {
+	items, err := s.client.ReadDir(s.workDir)
+	if err != nil {
+		return dir, err
+	}
+	existing := make([]string, 0, len(items))
+	for _, v := range items {
+		existing = append(existing, v.Name())
+	}
+	return createReleaseDir(s.step, existing, func(name string) error {
+		return s.client.Mkdir(path.Join(s.workDir, name))
+	})
+}
+
+// updateLatest replaces the latest pointer file with the release directory by an atomic rename
+func (s *sftpOperator) updateLatest(dir string) error {
+	name := s.step.Envs[types.PublisherReleaseLatestFile]
+	if name == "" {
+		return nil
+	}
+	from, to := path.Join(s.workDir, name+".tmp"), path.Join(s.workDir, name)
+	if err := s.writeFile(strings.NewReader(dir), from); err != nil {
+		return err
+	}
+	if err := s.client.PosixRename(from, to); err != nil {
+		// the server may not support the posix-rename@openssh.com extension
+		klog.V(4).Info(err)
+		if err = s.client.Remove(to); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return s.client.Rename(from, to)
+	}
+	return nil
+}
+
+func (s *sftpOperator) uploadFile(sourceFile, target string) error {
+	f, err := os.Open(sourceFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.writeFile(f, target)
+}
+
+func (s *sftpOperator) writeFile(r io.Reader, target string) error {
+	if err := s.client.MkdirAll(path.Dir(target)); err != nil {
+		return err
+	}
+	f, err := s.client.Create(target)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	s.output <- fmt.Sprintf("sftp uploaded %s (%d bytes)", target, n)
+	return nil
+}
@@ -0,0 +1,213 @@
+package operators
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	fakeSftpUsername = "publisher"
+	fakeSftpPassword = "secret"
+)
+
+// fakeSftpServer was an in-process ssh server which only serves the sftp subsystem on the local filesystem
+type fakeSftpServer struct {
+	listener  net.Listener
+	hostKey   ssh.Signer
+	clientKey string
+}
+
+func newFakeSftpServer(t *testing.T) *fakeSftpServer {
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey, err := ssh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPub, clientPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(clientPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKey := filepath.Join(t.TempDir(), "id_ed25519")
+	if err = ioutil.WriteFile(clientKey, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	authorized, err := ssh.NewPublicKey(clientPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == fakeSftpUsername && string(pass) == fakeSftpPassword {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %q", c.User())
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == fakeSftpUsername && string(key.Marshal()) == string(authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("public key rejected for %q", c.User())
+		},
+	}
+	config.AddHostKey(hostKey)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSftpServer{listener: l, hostKey: hostKey, clientKey: clientKey}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, config)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSftpServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for nc := range chans {
+		if nc.ChannelType() != "session" {
+			_ = nc.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := nc.Accept()
+		if err != nil {
+			return
+		}
+		go func(in <-chan *ssh.Request) {
+			for req := range in {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+			}
+		}(requests)
+		server, err := sftp.NewServer(channel)
+		if err != nil {
+			return
+		}
+		go func() {
+			_ = server.Serve()
+			_ = server.Close()
+		}()
+	}
+}
+
+func (s *fakeSftpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func Test_sftpOperator_Run(t *testing.T) {
+	server := newFakeSftpServer(t)
+	defer server.listener.Close()
+	source := filepath.Join(t.TempDir(), "bundle.txt")
+	if err := ioutil.WriteFile(source, []byte("bundle"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := ssh.FingerprintSHA256(server.hostKey.PublicKey())
+	authorized := string(ssh.MarshalAuthorizedKey(server.hostKey.PublicKey()))
+	type fields struct {
+		password   string
+		privateKey string
+		hostKey    string
+		mkdir      bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name:    "Test_sftpOperator_Run_1",
+			fields:  fields{password: fakeSftpPassword, hostKey: fingerprint},
+			wantErr: false,
+		},
+		{
+			name:    "Test_sftpOperator_Run_2",
+			fields:  fields{privateKey: server.clientKey, hostKey: authorized, mkdir: true},
+			wantErr: false,
+		},
+		{
+			name:    "Test_sftpOperator_Run_3",
+			fields:  fields{password: fakeSftpPassword, hostKey: "SHA256:mismatched"},
+			wantErr: true,
+		},
+		{
+			name:    "Test_sftpOperator_Run_4",
+			fields:  fields{password: "wrong", hostKey: fingerprint},
+			wantErr: true,
+		},
+		{
+			name:    "Test_sftpOperator_Run_5",
+			fields:  fields{password: fakeSftpPassword},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			s := NewSftp("127.0.0.1", server.port(), fakeSftpUsername, tt.fields.password, tt.fields.privateKey, tt.fields.hostKey, workDir, 5)
+			s.step.UploadFiles = []types.UploadFile{{SourceFile: source, TargetFile: "assets/bundle.txt"}}
+			s.step.WriteFiles = []types.WriteFile{{Content: []byte(`{"version":"1.0.0"}`), TargetFile: "version.json"}}
+			if tt.fields.mkdir {
+				s.step.Envs[types.PublisherFtpMkdir] = FtpMkdirMark
+			}
+			s.Prepare()
+			_, err := s.Run(make(chan string, 4096))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sftpOperator.Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if s.step.Phase != types.StepFailed {
+					t.Errorf("sftpOperator.Run() phase = %v, want %v", s.step.Phase, types.StepFailed)
+				}
+				return
+			}
+			dir := workDir
+			if tt.fields.mkdir {
+				want := fmt.Sprintf("%s_1", time.Now().Format(stepTemplateDateFormat))
+				if got := s.step.Envs[types.PublisherFtpMkdir]; got != want {
+					t.Errorf("sftpOperator.Run() dated dir = %v, want %v", got, want)
+				}
+				dir = filepath.Join(workDir, want)
+			}
+			for file, want := range map[string]string{"assets/bundle.txt": "bundle", "version.json": `{"version":"1.0.0"}`} {
+				got, err := ioutil.ReadFile(filepath.Join(dir, file))
+				if err != nil {
+					t.Errorf("sftpOperator.Run() file:%s err:%v", file, err)
+					continue
+				}
+				if strings.TrimSpace(string(got)) != want {
+					t.Errorf("sftpOperator.Run() file:%s = %s, want %s", file, got, want)
+				}
+			}
+		})
+	}
+}
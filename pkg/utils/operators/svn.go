@@ -2,11 +2,18 @@ package operators
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/Shanghai-Lunara/publisher/pkg/interfaces"
 	"github.com/Shanghai-Lunara/publisher/pkg/types"
 	"k8s.io/klog/v2"
-	"time"
 )
 
 func NewSvn(host string, port int, username, password, remoteDir, workDir string) interfaces.StepOperator {
@@ -54,15 +61,19 @@ func (s *svn) Prepare() {
 }
 
 const (
-	SvnCommandWaiting    = "pulling and waiting"
-	SvnCommandCommitting = "adding and committing"
-	SvnCommandCD         = "cd"
-	SvnCommandRevertAll  = "revert all"
-	SvnCommandRemoveAll  = "remove all"
-	SvnCommandCheckout   = "checkout"
-	SvnCommandAddAll     = "add all"
-	SvnCommandCommit     = "commit"
-	SvnCommandLog        = "log"
+	SvnCommandWaiting       = "pulling and waiting"
+	SvnCommandCommitting    = "adding and committing"
+	SvnCommandCD            = "cd"
+	SvnCommandRevertAll     = "revert all"
+	SvnCommandRemoveAll     = "remove all"
+	SvnCommandCheckout      = "checkout"
+	SvnCommandAddAll        = "add all"
+	SvnCommandCommit        = "commit"
+	SvnCommandLog           = "log"
+	SvnCommandUpdate        = "update"
+	SvnCommandSwitch        = "switch"
+	SvnCommandInfo          = "info"
+	SvnCommandDeleteMissing = "delete missing"
 )
 
 func (s *svn) AppendMessage(action string) {
@@ -97,13 +108,19 @@ func (s *svn) Run(output chan<- string) (res []string, err error) {
 			return res, err
 		}
 		res = append(res, string(out))
-		s.AppendMessage(SvnCommandCheckout)
-		if out, err = s.checkout(); err != nil {
+		s.AppendMessage(s.pullingCommand())
+		if out, err = s.pull(); err != nil {
 			klog.V(2).Info(err)
 			s.step.Phase = types.StepFailed
 			return res, err
 		}
 		res = append(res, string(out))
+		s.AppendMessage(SvnCommandInfo)
+		if err = s.resolve(); err != nil {
+			klog.V(2).Info(err)
+			s.step.Phase = types.StepFailed
+			return res, err
+		}
 	case SvnCommandCommitting:
 		s.AppendMessage(SvnCommandCommitting)
 		s.AppendMessage(SvnCommandAddAll)
@@ -113,14 +130,21 @@ func (s *svn) Run(output chan<- string) (res []string, err error) {
 			return res, err
 		}
 		res = append(res, string(out))
+		s.AppendMessage(SvnCommandDeleteMissing)
+		if out, err = s.deleteMissing(); err != nil {
+			klog.V(2).Info(err)
+			s.step.Phase = types.StepFailed
+			return res, err
+		}
+		res = append(res, string(out))
 		s.AppendMessage(SvnCommandCommit)
 		if out, err = s.commit(); err != nil {
 			klog.V(2).Info(err)
 			s.step.Phase = types.StepFailed
 			return res, err
 		}
-		s.AppendMessage(SvnCommandCheckout)
-		if out, err = s.checkout(); err != nil {
+		s.AppendMessage(SvnCommandUpdate)
+		if out, err = s.update(""); err != nil {
 			klog.V(2).Info(err)
 			s.step.Phase = types.StepFailed
 			return res, err
@@ -139,66 +163,279 @@ func (s *svn) Run(output chan<- string) (res []string, err error) {
 }
 
 func (s *svn) cd() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s", s.step.Envs[types.PublisherSvnWorkDir])
-	return DefaultExec(commands)
+	dir := s.step.Envs[types.PublisherSvnWorkDir]
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return nil, fmt.Errorf(ErrSvnWorkDirNotExisted, dir)
+	}
+	return nil, nil
 }
 
-const svnUrl = "svn://%s@%s:%s/%s"
+const (
+	svnUrl = "svn://%s@%s:%s/%s"
+
+	ErrSvnWorkDirNotExisted = "error: the svn work dir:%s was not existed"
+	ErrSvnAuthFailed        = "error: the svn authentication was failed: %s"
+	// svnArgsBatch limits the number of the paths in a single command
+	svnArgsBatch = 200
+)
+
+// svnAuthFailures were the error codes of svn when the credentials were refused
+var svnAuthFailures = []string{"E170001", "E170013", "E215004"}
 
-func (s *svn) checkout() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && svn --username %s --password %s checkout %s",
-		s.step.Envs[types.PublisherSvnWorkDir],
+// url returns the svn_url, or the svn:// url which was composed of the host, the port and the remote dir
+func (s *svn) url() string {
+	if v := s.step.Envs[types.PublisherSvnUrl]; v != "" {
+		return v
+	}
+	return fmt.Sprintf(svnUrl,
 		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-		fmt.Sprintf(svnUrl,
-			s.step.Envs[types.PublisherSvnUsername],
-			s.step.Envs[types.PublisherSvnHost],
-			s.step.Envs[types.PublisherSvnPort],
-			s.step.Envs[types.PublisherSvnRemoteDir]),
-	)
-	return ExecWithStreamOutput(commands, s.output)
+		s.step.Envs[types.PublisherSvnHost],
+		s.step.Envs[types.PublisherSvnPort],
+		s.step.Envs[types.PublisherSvnRemoteDir])
+}
+
+// workingCopy returns the dir of the working copy which was checked out into the work dir
+func (s *svn) workingCopy() string {
+	return filepath.Join(s.step.Envs[types.PublisherSvnWorkDir], s.step.Envs[types.PublisherSvnRemoteDir])
+}
+
+// isWorkingCopy returns whether the working copy had been checked out
+func (s *svn) isWorkingCopy() bool {
+	fi, err := os.Stat(filepath.Join(s.workingCopy(), ".svn"))
+	return err == nil && fi.IsDir()
+}
+
+// command returns the svn command with the args in the dir, it would never prompt. The remote commands carry the
+// username, and the password would be written into the stdin instead of the args, so that it would never be
+// visible in the `ps` or the output. The credentials in the auth cache would be used if the password was empty.
+func (s *svn) command(dir string, remote bool, args ...string) *exec.Cmd {
+	full := []string{"--non-interactive"}
+	var stdin string
+	if remote {
+		if v := s.step.Envs[types.PublisherSvnUsername]; v != "" {
+			full = append(full, "--username", v)
+		}
+		if v := s.step.Envs[types.PublisherSvnPassword]; v != "" {
+			full = append(full, "--password-from-stdin")
+			stdin = v + "\n"
+		}
+	}
+	cmd := exec.Command("svn", append(full, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	return cmd
+}
+
+// exec runs the svn command in the working copy and streams the stdout into the output
+func (s *svn) exec(remote bool, args ...string) ([]byte, error) {
+	out, err := execCmdWithStreamOutput(s.command(s.workingCopy(), remote, args...), s.output)
+	if err != nil {
+		return out, svnError(err)
+	}
+	return out, nil
+}
+
+// query runs the svn command in the working copy and returns the stdout without streaming it
+func (s *svn) query(remote bool, args ...string) ([]byte, error) {
+	cmd := s.command(s.workingCopy(), remote, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		e := &ExecError{Args: cmd.Args, ExitCode: -1, Err: err}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			e.ExitCode = exitErr.ExitCode()
+			e.Stderr = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		return out, svnError(e)
+	}
+	return out, nil
+}
+
+// svnError converts the ExecError whose stderr reported the refused credentials into the authentication error
+func svnError(err error) error {
+	var e *ExecError
+	if !errors.As(err, &e) {
+		return err
+	}
+	for _, v := range svnAuthFailures {
+		if strings.Contains(e.Stderr, v) {
+			return fmt.Errorf(ErrSvnAuthFailed, e.Stderr)
+		}
+	}
+	return err
+}
+
+// svnPathArg escapes the path which contains `@`, otherwise svn would parse the rest as the peg revision
+func svnPathArg(p string) string {
+	if strings.Contains(p, "@") {
+		return p + "@"
+	}
+	return p
+}
+
+// execPaths runs the svn command with the paths in batches, the paths were placed after `--`
+func (s *svn) execPaths(args []string, paths []string) (res []byte, err error) {
+	for i := 0; i < len(paths); i += svnArgsBatch {
+		j := i + svnArgsBatch
+		if j > len(paths) {
+			j = len(paths)
+		}
+		batch := append(append([]string{}, args...), "--")
+		for _, v := range paths[i:j] {
+			batch = append(batch, svnPathArg(v))
+		}
+		out, err := s.exec(false, batch...)
+		res = append(res, out...)
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// checkout checks out the url at the revision into the working copy, the head would be checked out if it was empty
+func (s *svn) checkout(url, revision string) (res []byte, err error) {
+	args := []string{"checkout"}
+	if revision != "" {
+		args = append(args, "--revision", revision)
+	}
+	cmd := s.command(s.step.Envs[types.PublisherSvnWorkDir], true, append(args, "--", url, s.workingCopy())...)
+	if res, err = execCmdWithStreamOutput(cmd, s.output); err != nil {
+		return res, svnError(err)
+	}
+	return res, nil
 }
 
+// addAll adds the unversioned files which were listed by the `svn status`
 func (s *svn) addAll() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && svn --username %s --password %s status | grep ? | awk '{print $2}' | xargs svn --username %s --password %s add",
-		fmt.Sprintf("%s/%s", s.step.Envs[types.PublisherSvnWorkDir], s.step.Envs[types.PublisherSvnRemoteDir]),
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-	)
-	return ExecWithStreamOutput(commands, s.output)
+	entries, err := s.status()
+	if err != nil {
+		return nil, err
+	}
+	paths := entries.paths(SvnItemUnversioned)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if res, err = s.execPaths([]string{"add", "--parents", "--depth", "infinity"}, paths); err != nil {
+		return res, err
+	}
+	for _, v := range paths {
+		s.output <- fmt.Sprintf("svn added %s", v)
+	}
+	return res, nil
 }
 
+// revertAll reverts all the local modifications in the working copy recursively
 func (s *svn) revertAll() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && svn --username %s --password %s status | awk '{print $2}' | xargs svn --username %s --password %s revert --depth infinity",
-		fmt.Sprintf("%s/%s", s.step.Envs[types.PublisherSvnWorkDir], s.step.Envs[types.PublisherSvnRemoteDir]),
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-	)
-	return ExecWithStreamOutput(commands, s.output)
+	if !s.isWorkingCopy() {
+		return nil, nil
+	}
+	return s.exec(false, "revert", "--depth", "infinity", ".")
 }
 
+// removeAll removes the unversioned and the ignored files which were listed by the `svn status`
 func (s *svn) removeAll() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && svn --username %s --password %s status | grep ? | awk '{print $2}' | xargs rm -rf",
-		fmt.Sprintf("%s/%s", s.step.Envs[types.PublisherSvnWorkDir], s.step.Envs[types.PublisherSvnRemoteDir]),
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-	)
-	return ExecWithStreamOutput(commands, s.output)
+	if !s.isWorkingCopy() {
+		return nil, nil
+	}
+	entries, err := s.status("--no-ignore")
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range entries.paths(SvnItemUnversioned, SvnItemIgnored) {
+		if err = os.RemoveAll(filepath.Join(s.workingCopy(), v)); err != nil {
+			return res, err
+		}
+		s.output <- fmt.Sprintf("svn removed %s", v)
+	}
+	return res, nil
 }
 
-func (s *svn) commit() (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && svn --username %s --password %s commit --message \"%s - committed by %s@publisher\"",
-		fmt.Sprintf("%s/%s", s.step.Envs[types.PublisherSvnWorkDir], s.step.Envs[types.PublisherSvnRemoteDir]),
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-		s.step.Envs[types.PublisherSvnCommitMessage],
-		s.step.Envs[types.PublisherSvnUsername],
-	)
-	return ExecWithStreamOutput(commands, s.output)
+const (
+	SvnItemUnversioned = "unversioned"
+	SvnItemIgnored     = "ignored"
+	SvnItemAdded       = "added"
+	SvnItemModified    = "modified"
+	SvnItemReplaced    = "replaced"
+	SvnItemDeleted     = "deleted"
+	SvnItemMissing     = "missing"
+	SvnItemConflicted  = "conflicted"
+	SvnItemObstructed  = "obstructed"
+)
+
+// StatusResponse was the output of the `svn status --xml`
+type StatusResponse struct {
+	XMLName xml.Name       `xml:"status"`
+	Targets []StatusTarget `xml:"target" json:"targets"`
+}
+
+type StatusTarget struct {
+	Path    string        `xml:"path,attr" json:"path,omitempty"`
+	Entries []StatusEntry `xml:"entry" json:"entries,omitempty"`
+}
+
+type StatusEntry struct {
+	Path     string   `xml:"path,attr" json:"path,omitempty"`
+	WcStatus WcStatus `xml:"wc-status" json:"wc_status"`
+}
+
+type WcStatus struct {
+	Item           string `xml:"item,attr" json:"item,omitempty"`
+	Props          string `xml:"props,attr" json:"props,omitempty"`
+	Revision       string `xml:"revision,attr" json:"revision,omitempty"`
+	TreeConflicted bool   `xml:"tree-conflicted,attr" json:"tree_conflicted,omitempty"`
+}
+
+type statusEntries []StatusEntry
+
+// paths returns the paths of the entries whose item was one of the items
+func (e statusEntries) paths(items ...string) []string {
+	res := make([]string, 0)
+	for _, v := range e {
+		for _, item := range items {
+			if v.WcStatus.Item == item {
+				res = append(res, v.Path)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// conflicted returns the paths which had the text, the property or the tree conflicts
+func (e statusEntries) conflicted() []string {
+	res := make([]string, 0)
+	for _, v := range e {
+		if v.WcStatus.Item == SvnItemConflicted || v.WcStatus.Props == SvnItemConflicted || v.WcStatus.TreeConflicted {
+			res = append(res, v.Path)
+		}
+	}
+	return res
+}
+
+// parseStatus parses the output of the `svn status --xml`
+func parseStatus(data []byte) (statusEntries, error) {
+	r := &StatusResponse{}
+	if err := xml.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	res := make(statusEntries, 0)
+	for _, t := range r.Targets {
+		res = append(res, t.Entries...)
+	}
+	return res, nil
+}
+
+// status returns the entries of the `svn status --xml` in the working copy, the paths were relative to it
+func (s *svn) status(args ...string) (statusEntries, error) {
+	out, err := s.query(false, append([]string{"status", "--xml"}, append(args, ".")...)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseStatus(out)
 }
 
 type LogResponse struct {
@@ -230,13 +467,7 @@ Msg:        %s
 `
 
 func (s *svn) log(number int) (res []byte, err error) {
-	commands := fmt.Sprintf("cd %s && svn --username %s --password %s log -l %d -v --xml",
-		fmt.Sprintf("%s/%s", s.step.Envs[types.PublisherSvnWorkDir], s.step.Envs[types.PublisherSvnRemoteDir]),
-		s.step.Envs[types.PublisherSvnUsername],
-		s.step.Envs[types.PublisherSvnPassword],
-		number,
-	)
-	res, err = DefaultExec(commands)
+	res, err = s.query(true, "log", "--limit", strconv.Itoa(number), "--verbose", "--xml", ".")
 	if err != nil {
 		klog.V(2).Info(err)
 		return nil, err
@@ -0,0 +1,145 @@
+package operators
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+const (
+	ErrSvnCommitLimitInvalid = "error: the %s:%s should be a non-negative integer"
+	ErrSvnCommitTooManyFiles = "error: the svn commit had %d changed files, it exceeded the %s:%d"
+	ErrSvnCommitTooLarge     = "error: the svn commit had %d bytes, it exceeded the %s:%d"
+	svnNothingToCommit       = "svn: nothing to commit, the working copy was clean"
+	svnCommitSummary         = "svn changes: %d added, %d modified, %d deleted, %s"
+	// svnCommitSummaryLines limits the changed files which were listed in the summary remark
+	svnCommitSummaryLines = 50
+)
+
+// svnChangeCodes were the codes of the items in the summary, such as `A path`
+var svnChangeCodes = map[string]string{
+	SvnItemAdded:    "A",
+	SvnItemModified: "M",
+	SvnItemReplaced: "R",
+	SvnItemDeleted:  "D",
+}
+
+// deleteMissing schedules the deletes of the files which were removed without the `svn delete`
+func (s *svn) deleteMissing() (res []byte, err error) {
+	entries, err := s.status()
+	if err != nil {
+		return nil, err
+	}
+	paths := entries.paths(SvnItemMissing)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if res, err = s.execPaths([]string{"delete", "--force"}, paths); err != nil {
+		return res, err
+	}
+	for _, v := range paths {
+		s.output <- fmt.Sprintf("svn deleted %s", v)
+	}
+	return res, nil
+}
+
+// svnChanges was the summary of the changes which would be committed
+type svnChanges struct {
+	added, modified, deleted int
+	bytes                    int64
+	lines                    []string
+}
+
+// changes summarizes the entries, the property changes were counted as the modified ones
+func (s *svn) changes(entries statusEntries) *svnChanges {
+	c := &svnChanges{lines: make([]string, 0)}
+	for _, v := range entries {
+		item := v.WcStatus.Item
+		if item == "normal" && v.WcStatus.Props == SvnItemModified {
+			item = SvnItemModified
+		}
+		code, ok := svnChangeCodes[item]
+		if !ok {
+			continue
+		}
+		switch item {
+		case SvnItemAdded:
+			c.added++
+		case SvnItemModified, SvnItemReplaced:
+			c.modified++
+		case SvnItemDeleted:
+			c.deleted++
+		}
+		if item != SvnItemDeleted {
+			if fi, err := os.Stat(filepath.Join(s.workingCopy(), v.Path)); err == nil && !fi.IsDir() {
+				c.bytes += fi.Size()
+			}
+		}
+		c.lines = append(c.lines, fmt.Sprintf("%s %s", code, v.Path))
+	}
+	return c
+}
+
+func (c *svnChanges) total() int {
+	return c.added + c.modified + c.deleted
+}
+
+// remark returns the summary with the changed files, the list would be truncated
+func (c *svnChanges) remark() string {
+	lines := c.lines
+	if len(lines) > svnCommitSummaryLines {
+		lines = append(lines[:svnCommitSummaryLines:svnCommitSummaryLines], fmt.Sprintf("... %d more", len(c.lines)-svnCommitSummaryLines))
+	}
+	return fmt.Sprintf(svnCommitSummary, c.added, c.modified, c.deleted, formatBytes(float64(c.bytes))) + "\n" + strings.Join(lines, "\n")
+}
+
+// commitLimit returns the guard of the key, zero means no limit
+func (s *svn) commitLimit(key string) (int64, error) {
+	v := strings.TrimSpace(s.step.Envs[key])
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf(ErrSvnCommitLimitInvalid, key, v)
+	}
+	return n, nil
+}
+
+// commit commits the scheduled changes after the summary was put into the Remarks. It succeeds without committing
+// when there was no change, and it fails when the changes exceeded the guards.
+func (s *svn) commit() (res []byte, err error) {
+	entries, err := s.status()
+	if err != nil {
+		return nil, err
+	}
+	c := s.changes(entries)
+	if c.total() == 0 {
+		s.step.Remarks = append(s.step.Remarks, svnNothingToCommit)
+		s.output <- svnNothingToCommit
+		return nil, nil
+	}
+	s.step.Remarks = append(s.step.Remarks, c.remark())
+	maxFiles, err := s.commitLimit(types.PublisherSvnCommitMaxFiles)
+	if err != nil {
+		return nil, err
+	}
+	if maxFiles > 0 && int64(c.total()) > maxFiles {
+		return nil, fmt.Errorf(ErrSvnCommitTooManyFiles, c.total(), types.PublisherSvnCommitMaxFiles, maxFiles)
+	}
+	maxBytes, err := s.commitLimit(types.PublisherSvnCommitMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes > 0 && c.bytes > maxBytes {
+		return nil, fmt.Errorf(ErrSvnCommitTooLarge, c.bytes, types.PublisherSvnCommitMaxBytes, maxBytes)
+	}
+	message := fmt.Sprintf("%s - committed by %s@publisher",
+		s.step.Envs[types.PublisherSvnCommitMessage],
+		s.step.Envs[types.PublisherSvnUsername])
+	return s.exec(true, "commit", "--message", message, ".")
+}
@@ -0,0 +1,216 @@
+package operators
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	SvnModeCheckout = "checkout"
+	SvnModeUpdate   = "update"
+
+	ErrSvnModeUnknown       = "error: unknown svn mode:%s"
+	ErrSvnRevisionInvalid   = "error: the svn revision:%q should be a number or HEAD"
+	ErrSvnSwitchUrlInvalid  = "error: the svn switch url:%q was invalid"
+	ErrSvnSwitchUrlRelative = "error: the relative svn switch url:%s needs an existing working copy"
+	ErrSvnConflicted        = "error: the svn working copy had %d conflicted paths: %s"
+	ErrSvnInfoEmpty         = "error: the svn info of the working copy was empty"
+	svnConflictMessage      = "svn conflicted: %s"
+	svnResolvedRemark       = types.SvnRevisionRemarkFormat
+)
+
+var svnRevisionPattern = regexp.MustCompile(`^([0-9]+|HEAD)$`)
+
+// InfoResponse was the output of the `svn info --xml`
+type InfoResponse struct {
+	XMLName xml.Name    `xml:"info"`
+	Entries []InfoEntry `xml:"entry" json:"entries"`
+}
+
+type InfoEntry struct {
+	Revision   string         `xml:"revision,attr" json:"revision,omitempty"`
+	URL        string         `xml:"url" json:"url,omitempty"`
+	Repository InfoRepository `xml:"repository" json:"repository"`
+}
+
+type InfoRepository struct {
+	Root string `xml:"root" json:"root,omitempty"`
+}
+
+// info returns the entry of the working copy root
+func (s *svn) info() (*InfoEntry, error) {
+	out, err := s.query(false, "info", "--xml", ".")
+	if err != nil {
+		return nil, err
+	}
+	r := &InfoResponse{}
+	if err = xml.Unmarshal(out, r); err != nil {
+		return nil, err
+	}
+	if len(r.Entries) == 0 {
+		return nil, errors.New(ErrSvnInfoEmpty)
+	}
+	return &r.Entries[0], nil
+}
+
+// revision returns the pinned revision, the empty one means the head
+func (s *svn) revision() (string, error) {
+	v := strings.TrimSpace(s.step.Envs[types.PublisherSvnRevision])
+	if v == "" {
+		return "", nil
+	}
+	if !svnRevisionPattern.MatchString(v) {
+		return "", fmt.Errorf(ErrSvnRevisionInvalid, v)
+	}
+	return v, nil
+}
+
+func (s *svn) mode() (string, error) {
+	switch v := s.step.Envs[types.PublisherSvnMode]; v {
+	case "", SvnModeCheckout:
+		return SvnModeCheckout, nil
+	case SvnModeUpdate:
+		return SvnModeUpdate, nil
+	default:
+		return "", fmt.Errorf(ErrSvnModeUnknown, v)
+	}
+}
+
+// pullingCommand returns the action of the pulling for the Messages
+func (s *svn) pullingCommand() string {
+	if s.step.Envs[types.PublisherSvnSwitchUrl] != "" && s.isWorkingCopy() {
+		return SvnCommandSwitch
+	}
+	if mode, _ := s.mode(); mode == SvnModeUpdate && s.isWorkingCopy() {
+		return SvnCommandUpdate
+	}
+	return SvnCommandCheckout
+}
+
+// target returns the absolute url which would be pulled, the url relative to the repository root such as
+// ^/branches/dev would be resolved by the existing working copy
+func (s *svn) target(wc *InfoEntry) (string, error) {
+	v := s.step.Envs[types.PublisherSvnSwitchUrl]
+	if v == "" {
+		return s.url(), nil
+	}
+	if strings.HasPrefix(v, "-") {
+		return "", fmt.Errorf(ErrSvnSwitchUrlInvalid, v)
+	}
+	if !strings.HasPrefix(v, "^/") {
+		return v, nil
+	}
+	if wc == nil {
+		return "", fmt.Errorf(ErrSvnSwitchUrlRelative, v)
+	}
+	return strings.TrimSuffix(wc.Repository.Root, "/") + "/" + strings.TrimPrefix(v, "^/"), nil
+}
+
+// pull brings the working copy to the target url at the pinned revision. The `update` mode updates or switches the
+// existing working copy in place, and the `checkout` mode checks it out again when the url was changed.
+// The conflicts would never be resolved automatically, they fail the Step with the paths in the Messages.
+func (s *svn) pull() (res []byte, err error) {
+	mode, err := s.mode()
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.revision()
+	if err != nil {
+		return nil, err
+	}
+	var wc *InfoEntry
+	if s.isWorkingCopy() {
+		if wc, err = s.info(); err != nil {
+			return nil, err
+		}
+	}
+	target, err := s.target(wc)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case wc != nil && mode == SvnModeUpdate && sameSvnUrl(target, wc.URL):
+		res, err = s.update(revision)
+	case wc != nil && mode == SvnModeUpdate:
+		res, err = s.switchTo(target, revision)
+	case wc != nil && !sameSvnUrl(target, wc.URL):
+		klog.Infof("svn remove the working copy of url:%s for checking out url:%s", wc.URL, target)
+		if err = os.RemoveAll(s.workingCopy()); err != nil {
+			return nil, err
+		}
+		fallthrough
+	default:
+		res, err = s.checkout(target, revision)
+	}
+	if err != nil {
+		return res, err
+	}
+	return res, s.conflicts()
+}
+
+// sameSvnUrl compares the urls after unescaping, the `svn info` returns the escaped one
+func sameSvnUrl(a, b string) bool {
+	unescape := func(v string) string {
+		if t, err := url.PathUnescape(v); err == nil {
+			v = t
+		}
+		return strings.TrimSuffix(v, "/")
+	}
+	return unescape(a) == unescape(b)
+}
+
+// update updates the working copy to the revision, the head would be updated if it was empty
+func (s *svn) update(revision string) (res []byte, err error) {
+	args := []string{"update", "--accept", "postpone"}
+	if revision != "" {
+		args = append(args, "--revision", revision)
+	}
+	return s.exec(true, append(args, ".")...)
+}
+
+func (s *svn) switchTo(target, revision string) (res []byte, err error) {
+	args := []string{"switch", "--accept", "postpone"}
+	if revision != "" {
+		args = append(args, "--revision", revision)
+	}
+	return s.exec(true, append(args, "--", target, ".")...)
+}
+
+// conflicts returns the error with the conflicted paths, and the paths would be appended into the Messages
+func (s *svn) conflicts() error {
+	entries, err := s.status()
+	if err != nil {
+		return err
+	}
+	paths := entries.conflicted()
+	if len(paths) == 0 {
+		return nil
+	}
+	for _, v := range paths {
+		s.step.Messages = append(s.step.Messages, fmt.Sprintf(svnConflictMessage, v))
+	}
+	return fmt.Errorf(ErrSvnConflicted, len(paths), strings.Join(paths, ", "))
+}
+
+// resolve puts the revision and the url of the working copy into the SharingData and the Remarks
+func (s *svn) resolve() error {
+	wc, err := s.info()
+	if err != nil {
+		return err
+	}
+	if s.step.SharingData == nil {
+		s.step.SharingData = make(map[string]string, 0)
+	}
+	s.step.SharingData[types.PublisherSvnResolvedRevision] = wc.Revision
+	s.step.SharingData[types.PublisherSvnResolvedUrl] = wc.URL
+	s.step.Remarks = append(s.step.Remarks, fmt.Sprintf(svnResolvedRemark, wc.Revision, wc.URL))
+	return nil
+}
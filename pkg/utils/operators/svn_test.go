@@ -0,0 +1,432 @@
+package operators
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_parseStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "Test_parseStatus_1",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<status>
+<target path=".">
+<entry path="a b.txt">
+<wc-status item="unversioned" props="none"></wc-status>
+</entry>
+<entry path="dir/x@y.txt">
+<wc-status item="modified" props="none" revision="3">
+<commit revision="2"><author>publisher</author><date>2020-10-30T04:00:00.000000Z</date></commit>
+</wc-status>
+</entry>
+<entry path="removed.txt">
+<wc-status item="missing" props="none"></wc-status>
+</entry>
+<entry path="c.txt">
+<wc-status item="unversioned" props="none"></wc-status>
+</entry>
+</target>
+</status>`,
+			want: map[string][]string{
+				SvnItemUnversioned: {"a b.txt", "c.txt"},
+				SvnItemModified:    {"dir/x@y.txt"},
+				SvnItemMissing:     {"removed.txt"},
+				SvnItemConflicted:  {},
+			},
+		},
+		{
+			name: "Test_parseStatus_2",
+			data: `<?xml version="1.0" encoding="UTF-8"?><status><target path="."></target></status>`,
+			want: map[string][]string{SvnItemUnversioned: {}},
+		},
+		{
+			name:    "Test_parseStatus_3",
+			data:    `svn: E155007: '/tmp' is not a working copy`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStatus([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for item, want := range tt.want {
+				if paths := got.paths(item); !reflect.DeepEqual(paths, want) {
+					t.Errorf("parseStatus().paths(%s) = %v, want %v", item, paths, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_svnPathArg(t *testing.T) {
+	for _, tt := range []struct{ path, want string }{
+		{"a b.txt", "a b.txt"},
+		{"x@y.txt", "x@y.txt@"},
+	} {
+		if got := svnPathArg(tt.path); got != tt.want {
+			t.Errorf("svnPathArg(%s) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+// newSvnFixture creates a local repository by the svnadmin, and an operator whose working copy was in the work dir
+func newSvnFixture(t *testing.T) (s *svn, url string) {
+	t.Helper()
+	for _, v := range []string{"svn", "svnadmin"} {
+		if _, err := exec.LookPath(v); err != nil {
+			t.Skipf("%s was not installed", v)
+		}
+	}
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	if out, err := exec.Command("svnadmin", "create", repo).CombinedOutput(); err != nil {
+		t.Fatalf("svnadmin create err:%v output:%s", err, out)
+	}
+	workDir := filepath.Join(root, "work")
+	if err := os.Mkdir(workDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	url = "file://" + filepath.ToSlash(repo)
+	s = NewSvn("", 0, "", "", "wc", workDir).(*svn)
+	s.step.Envs[types.PublisherSvnUrl] = url
+	return s, url
+}
+
+func Test_svn_Run(t *testing.T) {
+	s, _ := newSvnFixture(t)
+	wc := s.workingCopy()
+	run := func(command string) {
+		t.Helper()
+		s.Prepare()
+		s.step.Envs[types.PublisherSvnCommand] = command
+		if _, err := s.Run(make(chan string, 4096)); err != nil {
+			t.Fatalf("svn.Run(%s) error = %v", command, err)
+		}
+	}
+	run(SvnCommandWaiting)
+	for name, content := range map[string]string{
+		"a b.txt":      "a",
+		"x@y.txt":      "x",
+		"dir/nested.c": "n",
+	} {
+		_ = os.MkdirAll(filepath.Dir(filepath.Join(wc, name)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(wc, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run(SvnCommandCommitting)
+	if len(s.step.Remarks) == 0 || !strings.Contains(s.step.Remarks[len(s.step.Remarks)-1], "Revision:   1") {
+		t.Fatalf("Remarks = %v, want the revision 1", s.step.Remarks)
+	}
+	entries, err := s.status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("status after committing = %v, want clean", entries)
+	}
+	_ = ioutil.WriteFile(filepath.Join(wc, "a b.txt"), []byte("changed"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(wc, "junk.txt"), []byte("junk"), 0644)
+	run(SvnCommandWaiting)
+	if content, _ := ioutil.ReadFile(filepath.Join(wc, "a b.txt")); string(content) != "a" {
+		t.Errorf("a b.txt = %q, want the reverted content", content)
+	}
+	if _, err := os.Stat(filepath.Join(wc, "junk.txt")); !os.IsNotExist(err) {
+		t.Errorf("junk.txt was not removed, err:%v", err)
+	}
+}
+
+func Test_svn_target(t *testing.T) {
+	wc := &InfoEntry{URL: "file:///data/svn/project/trunk", Repository: InfoRepository{Root: "file:///data/svn/project"}}
+	tests := []struct {
+		name    string
+		envs    map[string]string
+		wc      *InfoEntry
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Test_svn_target_1",
+			envs: map[string]string{types.PublisherSvnUrl: "file:///data/svn/project/trunk"},
+			want: "file:///data/svn/project/trunk",
+		},
+		{
+			name: "Test_svn_target_2",
+			envs: map[string]string{types.PublisherSvnSwitchUrl: "^/tags/1.0.0"},
+			wc:   wc,
+			want: "file:///data/svn/project/tags/1.0.0",
+		},
+		{
+			name:    "Test_svn_target_3",
+			envs:    map[string]string{types.PublisherSvnSwitchUrl: "^/tags/1.0.0"},
+			wantErr: true,
+		},
+		{
+			name: "Test_svn_target_4",
+			envs: map[string]string{types.PublisherSvnSwitchUrl: "svn://127.0.0.1/project/branches/dev"},
+			want: "svn://127.0.0.1/project/branches/dev",
+		},
+		{
+			name:    "Test_svn_target_5",
+			envs:    map[string]string{types.PublisherSvnSwitchUrl: "--config-dir=/tmp"},
+			wc:      wc,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSvn("127.0.0.1", 3690, "publisher", "", "project/trunk", "/tmp").(*svn)
+			for k, v := range tt.envs {
+				s.step.Envs[k] = v
+			}
+			got, err := s.target(tt.wc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("svn.target() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("svn.target() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_svn_revision(t *testing.T) {
+	for _, tt := range []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"42", "42", false},
+		{"HEAD", "HEAD", false},
+		{"42; rm -rf /", "", true},
+		{"-r", "", true},
+	} {
+		s := NewSvn("127.0.0.1", 3690, "", "", "trunk", "/tmp").(*svn)
+		s.step.Envs[types.PublisherSvnRevision] = tt.value
+		got, err := s.revision()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("svn.revision(%q) = %v, %v, want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func Test_statusEntries_conflicted(t *testing.T) {
+	entries := statusEntries{
+		{Path: "a.txt", WcStatus: WcStatus{Item: SvnItemConflicted}},
+		{Path: "b.txt", WcStatus: WcStatus{Item: SvnItemModified, Props: SvnItemConflicted}},
+		{Path: "c", WcStatus: WcStatus{Item: SvnItemMissing, TreeConflicted: true}},
+		{Path: "d.txt", WcStatus: WcStatus{Item: SvnItemModified}},
+	}
+	if got, want := entries.conflicted(), []string{"a.txt", "b.txt", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("statusEntries.conflicted() = %v, want %v", got, want)
+	}
+}
+
+func Test_sameSvnUrl(t *testing.T) {
+	if !sameSvnUrl("file:///data/svn/my project/trunk/", "file:///data/svn/my%20project/trunk") {
+		t.Errorf("sameSvnUrl() = false, want true")
+	}
+	if sameSvnUrl("file:///data/svn/project/trunk", "file:///data/svn/project/tags/1.0.0") {
+		t.Errorf("sameSvnUrl() = true, want false")
+	}
+}
+
+func Test_svn_Run_pulling(t *testing.T) {
+	s, url := newSvnFixture(t)
+	wc := s.workingCopy()
+	run := func(command string, envs map[string]string) error {
+		t.Helper()
+		s.Prepare()
+		s.step.Envs[types.PublisherSvnCommand] = command
+		for k, v := range envs {
+			s.step.Envs[k] = v
+		}
+		_, err := s.Run(make(chan string, 4096))
+		return err
+	}
+	// r1 creates the trunk and the tags, and the working copy would be switched to the trunk
+	if err := run(SvnCommandWaiting, nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.MkdirAll(filepath.Join(wc, "trunk"), 0755)
+	_ = os.MkdirAll(filepath.Join(wc, "tags"), 0755)
+	_ = ioutil.WriteFile(filepath.Join(wc, "trunk", "version.txt"), []byte("1"), 0644)
+	if err := run(SvnCommandCommitting, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("svn", "copy", "--message", "tag", url+"/trunk", url+"/tags/1.0.0").CombinedOutput(); err != nil {
+		t.Fatalf("svn copy err:%v output:%s", err, out)
+	}
+	tests := []struct {
+		name     string
+		envs     map[string]string
+		want     string
+		revision string
+	}{
+		{
+			name:     "Test_svn_Run_pulling_1",
+			envs:     map[string]string{types.PublisherSvnMode: SvnModeUpdate, types.PublisherSvnSwitchUrl: "^/trunk"},
+			want:     "1",
+			revision: "2",
+		},
+		{
+			name:     "Test_svn_Run_pulling_2",
+			envs:     map[string]string{types.PublisherSvnSwitchUrl: "^/tags/1.0.0"},
+			want:     "1",
+			revision: "2",
+		},
+		{
+			name:     "Test_svn_Run_pulling_3",
+			envs:     map[string]string{types.PublisherSvnMode: SvnModeUpdate, types.PublisherSvnSwitchUrl: "", types.PublisherSvnRevision: "1"},
+			revision: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(SvnCommandWaiting, tt.envs); err != nil {
+				t.Fatalf("svn.Run() error = %v", err)
+			}
+			if got := s.step.SharingData[types.PublisherSvnResolvedRevision]; got != tt.revision {
+				t.Errorf("SharingData[%s] = %v, want %v", types.PublisherSvnResolvedRevision, got, tt.revision)
+			}
+			if tt.want == "" {
+				return
+			}
+			if content, _ := ioutil.ReadFile(filepath.Join(wc, "version.txt")); string(content) != tt.want {
+				t.Errorf("version.txt = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
+
+func Test_svn_changes(t *testing.T) {
+	workDir := t.TempDir()
+	s := NewSvn("127.0.0.1", 3690, "", "", "wc", workDir).(*svn)
+	_ = os.MkdirAll(filepath.Join(s.workingCopy(), "dir"), 0755)
+	_ = ioutil.WriteFile(filepath.Join(s.workingCopy(), "a.txt"), []byte("12345"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(s.workingCopy(), "b.txt"), []byte("123"), 0644)
+	entries := statusEntries{
+		{Path: "a.txt", WcStatus: WcStatus{Item: SvnItemAdded}},
+		{Path: "dir", WcStatus: WcStatus{Item: SvnItemAdded}},
+		{Path: "b.txt", WcStatus: WcStatus{Item: SvnItemModified}},
+		{Path: "c.txt", WcStatus: WcStatus{Item: SvnItemDeleted}},
+		{Path: "d.txt", WcStatus: WcStatus{Item: "normal", Props: SvnItemModified}},
+		{Path: "e.txt", WcStatus: WcStatus{Item: SvnItemUnversioned}},
+	}
+	c := s.changes(entries)
+	if c.added != 2 || c.modified != 2 || c.deleted != 1 || c.bytes != 8 {
+		t.Errorf("svn.changes() = %+v, want 2 added, 2 modified, 1 deleted, 8 bytes", c)
+	}
+	want := "svn changes: 2 added, 2 modified, 1 deleted, 8 B\nA a.txt\nA dir\nM b.txt\nD c.txt\nM d.txt"
+	if got := c.remark(); got != want {
+		t.Errorf("svnChanges.remark() = %q, want %q", got, want)
+	}
+}
+
+func Test_svn_commitLimit(t *testing.T) {
+	for _, tt := range []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"100", 100, false},
+		{"-1", 0, true},
+		{"1k", 0, true},
+	} {
+		s := NewSvn("127.0.0.1", 3690, "", "", "wc", "/tmp").(*svn)
+		s.step.Envs[types.PublisherSvnCommitMaxFiles] = tt.value
+		got, err := s.commitLimit(types.PublisherSvnCommitMaxFiles)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("svn.commitLimit(%q) = %v, %v, want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func Test_svn_Run_committing(t *testing.T) {
+	s, _ := newSvnFixture(t)
+	wc := s.workingCopy()
+	run := func(envs map[string]string) error {
+		t.Helper()
+		s.Prepare()
+		for k, v := range envs {
+			s.step.Envs[k] = v
+		}
+		_, err := s.Run(make(chan string, 4096))
+		return err
+	}
+	if err := run(map[string]string{types.PublisherSvnCommand: SvnCommandWaiting}); err != nil {
+		t.Fatal(err)
+	}
+	_ = ioutil.WriteFile(filepath.Join(wc, "a.txt"), []byte("a"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(wc, "b.txt"), []byte("b"), 0644)
+	tests := []struct {
+		name       string
+		envs       map[string]string
+		prepare    func()
+		wantRemark string
+		wantErr    string
+	}{
+		{
+			name:       "Test_svn_Run_committing_1",
+			envs:       map[string]string{types.PublisherSvnCommand: SvnCommandCommitting},
+			wantRemark: "svn changes: 2 added, 0 modified, 0 deleted",
+		},
+		{
+			name:       "Test_svn_Run_committing_2",
+			wantRemark: svnNothingToCommit,
+		},
+		{
+			name: "Test_svn_Run_committing_3",
+			prepare: func() {
+				_ = os.Remove(filepath.Join(wc, "b.txt"))
+			},
+			wantRemark: "svn changes: 0 added, 0 modified, 1 deleted",
+		},
+		{
+			name: "Test_svn_Run_committing_4",
+			envs: map[string]string{types.PublisherSvnCommitMaxFiles: "1"},
+			prepare: func() {
+				_ = ioutil.WriteFile(filepath.Join(wc, "c.txt"), []byte("c"), 0644)
+				_ = ioutil.WriteFile(filepath.Join(wc, "d.txt"), []byte("d"), 0644)
+			},
+			wantErr: "exceeded the svn_commit_max_files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prepare != nil {
+				tt.prepare()
+			}
+			err := run(tt.envs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("svn.Run() error = %v, wantErr %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("svn.Run() error = %v", err)
+			}
+			if len(s.step.Remarks) == 0 || !strings.HasPrefix(s.step.Remarks[0], tt.wantRemark) {
+				t.Errorf("Remarks = %v, want %s", s.step.Remarks, tt.wantRemark)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package operators
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+const (
+	stepTemplateDateFormat = "20060102"
+	stepTemplateTimeFormat = "2006-01-02 15:04:05"
+	stepTemplateUser       = "publisher"
+)
+
+// stepTemplateFuncs were the functions which could be called in the templates, the `json` quotes the value as a json string
+var stepTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// lookupStepValue returns the value in the Envs first, and then the SharingData
+func lookupStepValue(step *types.Step, key string) string {
+	if v, ok := step.Envs[key]; ok && v != "" {
+		return v
+	}
+	return step.SharingData[key]
+}
+
+// stepTemplateData was the data which could be referenced in the templates of all the operators,
+// such as the release directory, the git commit message, the docker tags, the kube manifest and the http hook
+type stepTemplateData struct {
+	// Version was the PUBLISHER_VERSION in the Envs or the SharingData
+	Version string
+	// GitSha and GitShortSha were the PUBLISHER_GIT_SHA and the PUBLISHER_GIT_SHORT_SHA of the git operator
+	GitSha      string
+	GitShortSha string
+	// User was the PUBLISHER_TRIGGER_USER, it would be `publisher` if the Step was not triggered by a dashboard user
+	User string
+	// Date and Time were the current date and time, such as 20201030 and 2020-10-30 12:00:00
+	Date string
+	Time string
+	// Seq was the sequence of the release directory starting from 1, it was 0 in the other templates
+	Seq         int
+	Envs        map[string]string
+	SharingData map[string]string
+}
+
+func newStepTemplateData(step *types.Step) stepTemplateData {
+	now := time.Now()
+	d := stepTemplateData{
+		Version:     lookupStepValue(step, types.PublisherVersion),
+		GitSha:      lookupStepValue(step, types.PublisherGitSha),
+		GitShortSha: lookupStepValue(step, types.PublisherGitShortSha),
+		User:        lookupStepValue(step, types.PublisherTriggerUser),
+		Date:        now.Format(stepTemplateDateFormat),
+		Time:        now.Format(stepTemplateTimeFormat),
+		Envs:        step.Envs,
+		SharingData: step.SharingData,
+	}
+	if d.User == "" {
+		d.User = stepTemplateUser
+	}
+	return d
+}
+
+// parseStepTemplate parses the text with the stepTemplateFuncs, the missing keys were rendered as the zero values
+func parseStepTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=zero").Funcs(stepTemplateFuncs).Parse(text)
+}
+
+// renderStepTemplate renders the text with the data of the Step
+func renderStepTemplate(text string, data stepTemplateData) (string, error) {
+	tmpl, err := parseStepTemplate("step", text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
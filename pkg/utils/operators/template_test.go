@@ -0,0 +1,61 @@
+package operators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+func Test_renderStepTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    *types.Step
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Test_renderStepTemplate_1",
+			step: &types.Step{
+				Envs:        map[string]string{types.PublisherVersion: "1.2.0"},
+				SharingData: map[string]string{types.PublisherVersion: "1.1.0", types.PublisherGitSha: "9e8e0b390897", types.PublisherGitShortSha: "9e8e0b3"},
+			},
+			text: "{{.Version}} {{.GitSha}} {{.GitShortSha}} {{.User}} {{.Seq}}",
+			want: "1.2.0 9e8e0b390897 9e8e0b3 publisher 0",
+		},
+		{
+			name: "Test_renderStepTemplate_2",
+			step: &types.Step{
+				Envs:        map[string]string{types.PublisherTriggerUser: "alice"},
+				SharingData: map[string]string{"NOTE": `a"b`},
+			},
+			text: `{"user":{{json .User}},"note":{{json .SharingData.NOTE}},"missing":{{json .Envs.missing}}}`,
+			want: `{"user":"alice","note":"a\"b","missing":""}`,
+		},
+		{
+			name: "Test_renderStepTemplate_3",
+			step: &types.Step{},
+			text: "{{.Date}}",
+			want: time.Now().Format(stepTemplateDateFormat),
+		},
+		{
+			name:    "Test_renderStepTemplate_4",
+			step:    &types.Step{},
+			text:    "{{.Date",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderStepTemplate(tt.text, newStepTemplateData(tt.step))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("renderStepTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("renderStepTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package operators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shanghai-Lunara/publisher/pkg/types"
+)
+
+const (
+	ErrUploadNoMatches    = "error: the upload source:%s matched no files"
+	ErrUploadTargetEmpty  = "error: neither the TargetFile nor the TargetPath of the upload source:%s was set"
+	ErrUploadSharedFiles  = "error: the PUBLISHER_UPLOAD_FILES in the SharingData was invalid: %v"
+	ErrWriteFileTarget    = "error: the TargetFile of the WriteFiles[%d] was empty"
+	uploadGlobMetaChars   = "*?["
+	uploadSummaryTemplate = "uploaded %d files, %s in %s (%s/s)"
+)
+
+// uploadTask was a single local file which would be uploaded to the target relative to the work dir
+type uploadTask struct {
+	source string
+	target string
+	size   int64
+}
+
+// stepUploadFiles returns the UploadFiles of the Step followed by the ones which were published
+// into the SharingData by the former Steps, such as the archive operator
+func stepUploadFiles(step *types.Step) ([]types.UploadFile, error) {
+	v := step.SharingData[types.PublisherUploadFiles]
+	if v == "" {
+		return step.UploadFiles, nil
+	}
+	shared := make([]types.UploadFile, 0)
+	if err := json.Unmarshal([]byte(v), &shared); err != nil {
+		return nil, fmt.Errorf(ErrUploadSharedFiles, err)
+	}
+	res := make([]types.UploadFile, 0, len(step.UploadFiles)+len(shared))
+	res = append(res, step.UploadFiles...)
+	return append(res, shared...), nil
+}
+
+// expandUploadFiles expands the directories and the glob patterns in the SourceFile into single files.
+// A directory was mirrored recursively under the TargetPath, and so were the matches of a glob pattern
+// relative to the pattern's static parent directory. A regular file was uploaded to its TargetFile,
+// or to the TargetPath with its base name when the TargetFile was empty.
+func expandUploadFiles(files []types.UploadFile) ([]uploadTask, error) {
+	tasks := make([]uploadTask, 0, len(files))
+	index := make(map[string]int, len(files))
+	add := func(t uploadTask) {
+		if i, ok := index[t.target]; ok {
+			tasks[i] = t
+			return
+		}
+		index[t.target] = len(tasks)
+		tasks = append(tasks, t)
+	}
+	for _, v := range files {
+		if strings.ContainsAny(v.SourceFile, uploadGlobMetaChars) {
+			matches, err := filepath.Glob(v.SourceFile)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf(ErrUploadNoMatches, v.SourceFile)
+			}
+			base := globBase(v.SourceFile)
+			for _, m := range matches {
+				if err = walkUploadSource(m, base, v.TargetPath, add); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		fi, err := os.Stat(v.SourceFile)
+		if err != nil {
+			return nil, err
+		}
+		if fi.IsDir() {
+			if err = walkUploadSource(v.SourceFile, v.SourceFile, v.TargetPath, add); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		target := v.TargetFile
+		if target == "" {
+			if v.TargetPath == "" {
+				return nil, fmt.Errorf(ErrUploadTargetEmpty, v.SourceFile)
+			}
+			target = path.Join(v.TargetPath, filepath.Base(v.SourceFile))
+		}
+		add(uploadTask{source: v.SourceFile, target: target, size: fi.Size()})
+	}
+	return tasks, nil
+}
+
+// writeFileTasks stores the contents of the WriteFiles into the dir, so that they could be uploaded, verified and
+// synced as the other files
+func writeFileTasks(dir string, files []types.WriteFile) ([]uploadTask, error) {
+	tasks := make([]uploadTask, 0, len(files))
+	for i, v := range files {
+		if v.TargetFile == "" {
+			return nil, fmt.Errorf(ErrWriteFileTarget, i)
+		}
+		source := filepath.Join(dir, strconv.Itoa(i))
+		if err := ioutil.WriteFile(source, v.Content, 0644); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, uploadTask{source: source, target: path.Clean(v.TargetFile), size: int64(len(v.Content))})
+	}
+	return tasks, nil
+}
+
+// walkUploadSource adds all the regular files under the source, their targets were the relative paths to the base
+func walkUploadSource(source, base, targetPath string, add func(t uploadTask)) error {
+	return filepath.Walk(source, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+		add(uploadTask{source: p, target: path.Join(targetPath, filepath.ToSlash(rel)), size: info.Size()})
+		return nil
+	})
+}
+
+// globBase returns the longest leading directory of the pattern which has no glob meta characters
+func globBase(pattern string) string {
+	dir := filepath.Dir(pattern)
+	for strings.ContainsAny(dir, uploadGlobMetaChars) {
+		dir = filepath.Dir(dir)
+	}
+	return dir
+}
+
+// uploadDirs returns all the directories, including the ancestors, which need to exist before uploading the tasks.
+// Parents were always in front of their children.
+func uploadDirs(tasks []uploadTask) []string {
+	m := make(map[string]struct{}, 0)
+	for _, t := range tasks {
+		for dir := path.Dir(t.target); dir != "." && dir != "/" && dir != ""; dir = path.Dir(dir) {
+			m[dir] = struct{}{}
+		}
+	}
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// uploadSummary formats the files, bytes and the throughput of a run
+func uploadSummary(files int, bytes int64, elapsed time.Duration) string {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		seconds = 1e-9
+	}
+	return fmt.Sprintf(uploadSummaryTemplate, files, formatBytes(float64(bytes)), elapsed.Round(time.Millisecond), formatBytes(float64(bytes)/seconds))
+}
+
+func formatBytes(b float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for b >= 1024 && i < len(units)-1 {
+		b /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", b, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", b, units[i])
+}